@@ -0,0 +1,128 @@
+package border0
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGroup() *schema.Resource {
+	groupSchema := groupDataSourceAttributes()
+	groupSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "display_name"},
+		Description:  "The ID of the group to look up. Exactly one of `id` or `display_name` must be set.",
+	}
+	groupSchema["display_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "display_name"},
+		Description:  "The display name of the group to look up. Exactly one of `id` or `display_name` must be set. Display names are not unique, when more than one group has this display name the lookup fails, use `id` or the `border0_groups` data source to get all of them instead.",
+	}
+
+	return &schema.Resource{
+		Description: "`border0_group` data source can be used to look up an existing group, e.g. one that is not managed by terraform, by its ID or display name.",
+		ReadContext: dataSourceGroupRead,
+		Schema:      groupSchema,
+	}
+}
+
+func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	var group *border0client.Group
+	if id, ok := d.GetOk("id"); ok {
+		found, err := client.Group(ctx, id.(string))
+		if err != nil {
+			return diagnostics.Error(err, "Failed to fetch group")
+		}
+		group = found
+	} else {
+		displayName := d.Get("display_name").(string)
+
+		// client.Groups drains the go sdk's GroupsPaginator with its default page size, so every page
+		// of groups is searched, there's no lookup by display name to use instead.
+		groups, err := client.Groups(ctx)
+		if err != nil {
+			return diagnostics.Error(err, "Failed to fetch groups list")
+		}
+
+		var matched []border0client.Group
+		for _, g := range groups.List {
+			if g.DisplayName == displayName {
+				matched = append(matched, g)
+			}
+		}
+		switch len(matched) {
+		case 0:
+			return diag.Errorf("Group with display name %q not found", displayName)
+		case 1:
+			group = &matched[0]
+		default:
+			ids := make([]string, 0, len(matched))
+			for _, g := range matched {
+				ids = append(ids, g.ID)
+			}
+			sort.Strings(ids)
+			return diag.Errorf("Group display name %q matches more than one group (%s), use id or the border0_groups data source instead", displayName, strings.Join(ids, ", "))
+		}
+	}
+
+	d.SetId(group.ID)
+	return schemautil.SetValues(d, flattenGroup(group))
+}
+
+// groupDataSourceAttributes returns the computed attributes shared by the
+// border0_group data source and the elements of the border0_groups data source.
+func groupDataSourceAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the group.",
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The display name of the group.",
+		},
+		"members": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the users in the group, sorted.",
+		},
+		"member_emails": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The emails of the users in the group, sorted.",
+		},
+	}
+}
+
+func flattenGroup(group *border0client.Group) map[string]any {
+	members := make([]string, 0, len(group.Members))
+	memberEmails := make([]string, 0, len(group.Members))
+	for _, member := range group.Members {
+		members = append(members, member.ID)
+		memberEmails = append(memberEmails, member.Email)
+	}
+	sort.Strings(members)
+	sort.Strings(memberEmails)
+
+	return map[string]any{
+		"display_name":  group.DisplayName,
+		"members":       members,
+		"member_emails": memberEmails,
+	}
+}
@@ -0,0 +1,130 @@
+package border0_test
+
+import (
+	"regexp"
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+var mockOrgGroups = border0client.Groups{
+	List: []border0client.Group{
+		{
+			ID:          "unit-test-group-id-1",
+			DisplayName: "engineering",
+			Members: []border0client.User{
+				{ID: "unit-test-user-id-2", Email: "janedoe@example.com"},
+				{ID: "unit-test-user-id-1", Email: "johndoe@example.com"},
+			},
+		},
+		{ID: "unit-test-group-id-3", DisplayName: "contractors"},
+		{ID: "unit-test-group-id-2", DisplayName: "contractors"},
+	},
+}
+
+func Test_DataSource_Group_ByDisplayName(t *testing.T) {
+	config := `
+		data "border0_group" "unit_test" {
+			display_name = "engineering"
+		}`
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// refresh for startup
+		clientMock.EXPECT().Groups(matchContext).Return(&mockOrgGroups, nil).Call,
+
+		// refresh for apply, apply, and post-apply
+		clientMock.EXPECT().Groups(matchContext).Return(&mockOrgGroups, nil).Call,
+		clientMock.EXPECT().Groups(matchContext).Return(&mockOrgGroups, nil).Call,
+		clientMock.EXPECT().Groups(matchContext).Return(&mockOrgGroups, nil).Call,
+
+		// refresh for cleanup
+		clientMock.EXPECT().Groups(matchContext).Return(&mockOrgGroups, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "id", "unit-test-group-id-1"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "display_name", "engineering"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "members.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "members.0", "unit-test-user-id-1"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "members.1", "unit-test-user-id-2"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "member_emails.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "member_emails.0", "janedoe@example.com"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "member_emails.1", "johndoe@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_Group_ByID(t *testing.T) {
+	config := `
+		data "border0_group" "unit_test" {
+			id = "unit-test-group-id-1"
+		}`
+
+	group := mockOrgGroups.List[0]
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// refresh for startup
+		clientMock.EXPECT().Group(matchContext, "unit-test-group-id-1").Return(&group, nil).Call,
+
+		// refresh for apply, apply, and post-apply
+		clientMock.EXPECT().Group(matchContext, "unit-test-group-id-1").Return(&group, nil).Call,
+		clientMock.EXPECT().Group(matchContext, "unit-test-group-id-1").Return(&group, nil).Call,
+		clientMock.EXPECT().Group(matchContext, "unit-test-group-id-1").Return(&group, nil).Call,
+
+		// refresh for cleanup
+		clientMock.EXPECT().Group(matchContext, "unit-test-group-id-1").Return(&group, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "id", "unit-test-group-id-1"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "display_name", "engineering"),
+					resource.TestCheckResourceAttr("data.border0_group.unit_test", "members.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_Group_DuplicateOrMissingDisplayName(t *testing.T) {
+	clientMock := mocks.APIClientRequester{}
+	clientMock.EXPECT().Groups(matchContext).Return(&mockOrgGroups, nil)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "border0_group" "unit_test" {
+						display_name = "contractors"
+					}`,
+				ExpectError: regexp.MustCompile(`Group display name "contractors" matches more than one group \(unit-test-group-id-2, unit-test-group-id-3\)`),
+			},
+			{
+				Config: `
+					data "border0_group" "unit_test" {
+						display_name = "sales"
+					}`,
+				ExpectError: regexp.MustCompile(`Group with display name "sales" not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,85 @@
+package border0
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "`border0_groups` data source can be used to list the groups in the organization with their members, optionally filtered by display name.",
+		ReadContext: dataSourceGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return groups with this display name. Display names are not unique, all the groups with this display name are returned.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching groups, ordered by display name and ID.",
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching groups, ordered by display name and ID.",
+				Elem: &schema.Resource{
+					Schema: groupDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	// client.Groups drains the go sdk's GroupsPaginator with its default page size, so it lists every
+	// page of groups, the same as iterating over the paginator here would. The display name filter is
+	// applied afterwards because the groups endpoint can't filter by it.
+	groups, err := client.Groups(ctx)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch groups list")
+	}
+
+	displayName, filterByDisplayName := d.GetOk("display_name")
+
+	var matched []border0client.Group
+	for _, group := range groups.List {
+		if filterByDisplayName && group.DisplayName != displayName.(string) {
+			continue
+		}
+		matched = append(matched, group)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].DisplayName != matched[j].DisplayName {
+			return matched[i].DisplayName < matched[j].DisplayName
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	ids := make([]string, 0, len(matched))
+	flattenedGroups := make([]map[string]any, 0, len(matched))
+	for i := range matched {
+		flattened := flattenGroup(&matched[i])
+		flattened["id"] = matched[i].ID
+		ids = append(ids, matched[i].ID)
+		flattenedGroups = append(flattenedGroups, flattened)
+	}
+
+	d.SetId(strconv.Itoa(stringHashcode(strings.Join(ids, ","))))
+	return schemautil.SetValues(d, map[string]any{
+		"ids":    ids,
+		"groups": flattenedGroups,
+	})
+}
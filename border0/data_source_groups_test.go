@@ -0,0 +1,43 @@
+package border0_test
+
+import (
+	"testing"
+
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_DataSource_Groups(t *testing.T) {
+	config := `
+		data "border0_groups" "unit_test" {
+			display_name = "contractors"
+		}
+		data "border0_groups" "all" {}`
+
+	clientMock := mocks.APIClientRequester{}
+	// both data sources list the groups in every refresh
+	clientMock.EXPECT().Groups(matchContext).Return(&mockOrgGroups, nil).Times(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_groups.unit_test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_groups.unit_test", "ids.0", "unit-test-group-id-2"),
+					resource.TestCheckResourceAttr("data.border0_groups.unit_test", "ids.1", "unit-test-group-id-3"),
+					resource.TestCheckResourceAttr("data.border0_groups.unit_test", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_groups.unit_test", "groups.0.display_name", "contractors"),
+					resource.TestCheckResourceAttr("data.border0_groups.unit_test", "groups.0.members.#", "0"),
+
+					resource.TestCheckResourceAttr("data.border0_groups.all", "ids.#", "3"),
+					resource.TestCheckResourceAttr("data.border0_groups.all", "groups.2.id", "unit-test-group-id-1"),
+					resource.TestCheckResourceAttr("data.border0_groups.all", "groups.2.member_emails.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_groups.all", "groups.2.member_emails.0", "janedoe@example.com"),
+				),
+			},
+		},
+	})
+}
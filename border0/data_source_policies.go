@@ -0,0 +1,89 @@
+package border0
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePolicies() *schema.Resource {
+	return &schema.Resource{
+		Description: "`border0_policies` data source can be used to list the policies in the organization, optionally filtered by name prefix and whether they are org-wide.",
+		ReadContext: dataSourcePoliciesRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return policies whose name starts with this prefix.",
+			},
+			"org_wide": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "When set, only return policies whose `org_wide` flag matches this value.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching policies, ordered by policy name.",
+			},
+			"policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching policies, ordered by policy name.",
+				Elem: &schema.Resource{
+					Schema: policyDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePoliciesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	policies, err := client.Policies(ctx)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch policies")
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	orgWide := d.GetRawConfig().GetAttr("org_wide")
+
+	var matched []border0client.Policy
+	for _, policy := range policies {
+		if !strings.HasPrefix(policy.Name, namePrefix) {
+			continue
+		}
+		if !orgWide.IsNull() && policy.OrgWide != orgWide.True() {
+			continue
+		}
+		matched = append(matched, policy)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+
+	ids := make([]string, 0, len(matched))
+	flattenedPolicies := make([]map[string]any, 0, len(matched))
+	for i := range matched {
+		flattened, err := flattenPolicy(&matched[i])
+		if err != nil {
+			return diagnostics.Error(err, "Failed to process policy data for policy %s", matched[i].Name)
+		}
+		flattened["id"] = matched[i].ID
+		ids = append(ids, matched[i].ID)
+		flattenedPolicies = append(flattenedPolicies, flattened)
+	}
+
+	d.SetId(strconv.Itoa(stringHashcode(strings.Join(ids, ","))))
+	return schemautil.SetValues(d, map[string]any{
+		"ids":      ids,
+		"policies": flattenedPolicies,
+	})
+}
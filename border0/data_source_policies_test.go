@@ -0,0 +1,59 @@
+package border0_test
+
+import (
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_DataSource_Policies(t *testing.T) {
+	mockPoliciesResponse := []border0client.Policy{
+		{ID: "mock1-id", Name: "team-b-ssh", Version: "v2", OrgWide: false},
+		{ID: "mock2-id", Name: "baseline", Version: "v2", OrgWide: true},
+		{ID: "mock3-id", Name: "team-a-db", Version: "v2", OrgWide: false, SocketIDs: []string{"unit-test-socket-id"}},
+		{ID: "mock4-id", Name: "team-a-org", Version: "v1", OrgWide: true},
+	}
+
+	config := `
+		data "border0_policies" "unit_test" {
+			name_prefix = "team-"
+			org_wide    = false
+		}`
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// refresh for startup
+		clientMock.EXPECT().Policies(matchContext).Return(mockPoliciesResponse, nil).Call,
+
+		// refresh for apply, apply, and post-apply
+		clientMock.EXPECT().Policies(matchContext).Return(mockPoliciesResponse, nil).Call,
+		clientMock.EXPECT().Policies(matchContext).Return(mockPoliciesResponse, nil).Call,
+		clientMock.EXPECT().Policies(matchContext).Return(mockPoliciesResponse, nil).Call,
+
+		// refresh for cleanup
+		clientMock.EXPECT().Policies(matchContext).Return(mockPoliciesResponse, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "ids.0", "mock3-id"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "ids.1", "mock1-id"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "policies.0.name", "team-a-db"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "policies.0.socket_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "policies.0.socket_ids.0", "unit-test-socket-id"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "policies.1.name", "team-b-ssh"),
+					resource.TestCheckResourceAttr("data.border0_policies.unit_test", "policies.1.org_wide", "false"),
+				),
+			},
+		},
+	})
+}
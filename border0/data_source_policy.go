@@ -0,0 +1,146 @@
+package border0
+
+import (
+	"context"
+	"sort"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePolicy() *schema.Resource {
+	policySchema := policyDataSourceAttributes()
+	policySchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+		Description:  "The ID of the policy to look up. Exactly one of `id` or `name` must be set.",
+	}
+	policySchema["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+		Description:  "The name of the policy to look up. Exactly one of `id` or `name` must be set.",
+	}
+
+	return &schema.Resource{
+		Description: "`border0_policy` data source can be used to look up an existing policy, e.g. an org-managed policy that is not managed by terraform, by its ID or name.",
+		ReadContext: dataSourcePolicyRead,
+		Schema:      policySchema,
+	}
+}
+
+func dataSourcePolicyRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	var policy *border0client.Policy
+	if id, ok := d.GetOk("id"); ok {
+		found, err := client.Policy(ctx, id.(string))
+		if err != nil {
+			return diagnostics.Error(err, "Failed to fetch policy")
+		}
+		policy = found
+	} else {
+		name := d.Get("name").(string)
+		policies, err := client.PoliciesByNames(ctx, name)
+		if err != nil {
+			return diagnostics.Error(err, "Failed to fetch policies by name")
+		}
+		for i := range policies {
+			if policies[i].Name == name {
+				policy = &policies[i]
+				break
+			}
+		}
+		if policy == nil {
+			return diag.Errorf("Policy with name %q not found", name)
+		}
+	}
+
+	flattened, err := flattenPolicy(policy)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to process policy data")
+	}
+
+	d.SetId(policy.ID)
+	return schemautil.SetValues(d, flattened)
+}
+
+// policyDataSourceAttributes returns the computed attributes shared by the
+// border0_policy data source and the elements of the border0_policies data source.
+func policyDataSourceAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the policy.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the policy.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The description of the policy.",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The version of the policy, either `v1` or `v2`.",
+		},
+		"policy_data": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The policy data as a JSON string, normalized the same way as the `border0_policy` resource.",
+		},
+		"org_wide": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the policy is applied to all sockets in the organization.",
+		},
+		"tag_rules": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The tag rules of the policy.",
+			Elem: &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		"socket_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the sockets the policy is attached to, sorted.",
+		},
+	}
+}
+
+func flattenPolicy(policy *border0client.Policy) (map[string]any, error) {
+	policyData, err := normalizePolicyData(policy.PolicyData)
+	if err != nil {
+		return nil, err
+	}
+
+	socketIDs := append([]string{}, policy.SocketIDs...)
+	sort.Strings(socketIDs)
+
+	return map[string]any{
+		"name":        policy.Name,
+		"description": policy.Description,
+		"version":     policy.Version,
+		"policy_data": policyData,
+		"org_wide":    policy.OrgWide,
+		"tag_rules":   flattenTagRules(policy.TagRules),
+		"socket_ids":  socketIDs,
+	}, nil
+}
@@ -0,0 +1,190 @@
+package border0
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/policyutil"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePolicyEvaluation() *schema.Resource {
+	return &schema.Resource{
+		Description: "`border0_policy_evaluation` data source can be used to evaluate v2 policies against an access request locally, without calling the Border0 API. It is meant for testing access models with `check` blocks before they are applied. Access is denied by default, and allowed when any of the policies matches the request's condition and grants the requested permission.",
+		ReadContext: dataSourcePolicyEvaluationRead,
+		Schema: map[string]*schema.Schema{
+			"policy": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The v2 policies to evaluate the request against.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name the policy is reported by in `contributing_policies` and `reason`. Defaults to `policy[<index>]`.",
+						},
+						"policy_data": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "The v2 policy document in JSON format, e.g. from `border0_policy_v2_document`.",
+						},
+					},
+				},
+			},
+			"request": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The access request to evaluate. Attributes that are not set are unknown, and never satisfy a condition that restricts them.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The email of the user making the request.",
+						},
+						"groups": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IDs of the groups the user making the request belongs to.",
+						},
+						"service_account": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The service account making the request.",
+						},
+						"source_ip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+							Description:  "The IP address the request originates from.",
+						},
+						"country": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validatePolicyValue(policyutil.ValidateCountryCode),
+							Description:  "The ISO 3166-1 alpha-2 code of the country the request originates from.",
+						},
+						"timestamp": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+							Description:  "The time of the request in RFC 3339 format. Defaults to the current time, set it to keep the evaluation stable.",
+						},
+						"socket_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(policyutil.SocketTypes, false),
+							Description:  "The type of socket the request is for. Valid values are `ssh`, `database`, `http`, `kubernetes`, `tls`, `vnc`, `rdp` and `network`.",
+						},
+						"ssh_action": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(policyutil.SSHActions, false),
+							Description:  "The ssh action requested, only for `ssh` sockets. Valid values are `shell`, `exec`, `sftp`, `tcp_forwarding`, `kubectl_exec` and `docker_exec`. When not set, only access to the socket is evaluated.",
+						},
+						"ssh_username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The username requested, only for `ssh` sockets.",
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The database requested, only for `database` sockets.",
+						},
+						"query_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The query type requested, only for `database` sockets.",
+						},
+					},
+				},
+			},
+			"allowed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the request is allowed.",
+			},
+			"contributing_policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the policies that allow the request, in the order they are listed.",
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A human readable explanation of the decision. When the request is denied, it lists why each policy does not allow it.",
+			},
+		},
+	}
+}
+
+func dataSourcePolicyEvaluationRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	var policies []policyutil.NamedPolicy
+	for i, v := range d.Get("policy").([]any) {
+		policy := v.(map[string]any)
+
+		name := policy["name"].(string)
+		if name == "" {
+			name = fmt.Sprintf("policy[%d]", i)
+		}
+
+		var policyData border0client.PolicyDataV2
+		if err := json.Unmarshal([]byte(policy["policy_data"].(string)), &policyData); err != nil {
+			return diagnostics.Error(err, "Failed to unmarshal policy data of %s", name)
+		}
+		policies = append(policies, policyutil.NamedPolicy{Name: name, PolicyData: policyData})
+	}
+
+	request := d.Get("request").([]any)[0].(map[string]any)
+	evaluationRequest := policyutil.EvaluationRequest{
+		Email:          request["email"].(string),
+		ServiceAccount: request["service_account"].(string),
+		SourceIP:       request["source_ip"].(string),
+		Country:        request["country"].(string),
+		Time:           time.Now(),
+		SocketType:     request["socket_type"].(string),
+		SSHAction:      request["ssh_action"].(string),
+		SSHUsername:    request["ssh_username"].(string),
+		Database:       request["database"].(string),
+		QueryType:      request["query_type"].(string),
+	}
+	for _, group := range request["groups"].(*schema.Set).List() {
+		evaluationRequest.Groups = append(evaluationRequest.Groups, group.(string))
+	}
+	if timestamp := request["timestamp"].(string); timestamp != "" {
+		at, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return diagnostics.Error(err, "Failed to parse request timestamp")
+		}
+		evaluationRequest.Time = at
+	}
+
+	decision := policyutil.EvaluateV2(policies, evaluationRequest)
+
+	input, err := json.Marshal([]any{policies, evaluationRequest})
+	if err != nil {
+		return diagnostics.Error(err, "Failed to marshal policy evaluation input")
+	}
+
+	d.SetId(strconv.Itoa(stringHashcode(string(input))))
+	return schemautil.SetValues(d, map[string]any{
+		"allowed":               decision.Allowed,
+		"contributing_policies": decision.Policies,
+		"reason":                decision.Reason,
+	})
+}
@@ -0,0 +1,131 @@
+package border0_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+var policyEvaluationConfig = `
+data "border0_policy_v2_document" "engineering" {
+  permissions {
+    ssh {
+      allowed = true
+      shell {
+        allowed = true
+      }
+      use_allowed_usernames_list = true
+      allowed_usernames          = [ "ubuntu" ]
+    }
+  }
+  condition {
+    who {
+      group = [ "engineering-group-id" ]
+    }
+    where {
+      allowed_ip = [ "10.0.0.0/8" ]
+    }
+    when {
+      time_of_day_after  = "08:00 UTC"
+      time_of_day_before = "18:00 UTC"
+    }
+  }
+}
+
+data "border0_policy_v2_document" "database" {
+  permissions {
+    database {
+      allowed = true
+    }
+  }
+  condition {
+    who {
+      email = [ "johndoe@example.com" ]
+    }
+    where {}
+    when {}
+  }
+}
+
+data "border0_policy_evaluation" "shell_as_ubuntu" {
+  policy {
+    name        = "engineering"
+    policy_data = data.border0_policy_v2_document.engineering.json
+  }
+  policy {
+    policy_data = data.border0_policy_v2_document.database.json
+  }
+  request {
+    email        = "johndoe@example.com"
+    groups       = [ "engineering-group-id" ]
+    source_ip    = "10.1.2.3"
+    timestamp    = "2024-06-03T09:30:00Z"
+    socket_type  = "ssh"
+    ssh_action   = "shell"
+    ssh_username = "ubuntu"
+  }
+}
+
+data "border0_policy_evaluation" "shell_as_root" {
+  policy {
+    name        = "engineering"
+    policy_data = data.border0_policy_v2_document.engineering.json
+  }
+  policy {
+    policy_data = data.border0_policy_v2_document.database.json
+  }
+  request {
+    email        = "johndoe@example.com"
+    groups       = [ "engineering-group-id" ]
+    source_ip    = "10.1.2.3"
+    timestamp    = "2024-06-03T09:30:00Z"
+    socket_type  = "ssh"
+    ssh_action   = "shell"
+    ssh_username = "root"
+  }
+}
+`
+
+func Test_DataSource_PolicyEvaluation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: policyEvaluationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_policy_evaluation.shell_as_ubuntu", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.border0_policy_evaluation.shell_as_ubuntu", "contributing_policies.#", "1"),
+					resource.TestCheckResourceAttr("data.border0_policy_evaluation.shell_as_ubuntu", "contributing_policies.0", "engineering"),
+					resource.TestCheckResourceAttr("data.border0_policy_evaluation.shell_as_ubuntu", "reason", "allowed by engineering"),
+					resource.TestCheckResourceAttr("data.border0_policy_evaluation.shell_as_root", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.border0_policy_evaluation.shell_as_root", "contributing_policies.#", "0"),
+					resource.TestCheckResourceAttr("data.border0_policy_evaluation.shell_as_root", "reason", `denied, engineering: ssh username "root" is not in allowed_usernames; policy[1]: ssh access is not granted`),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_PolicyEvaluation_InvalidRequest(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "border0_policy_evaluation" "unit_test" {
+						policy {
+							policy_data = jsonencode({})
+						}
+						request {
+							socket_type = "ftp"
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`expected request.0.socket_type to be one of`),
+			},
+		},
+	})
+}
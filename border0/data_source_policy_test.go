@@ -0,0 +1,112 @@
+package border0_test
+
+import (
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+var mockOrgManagedPolicy = border0client.Policy{
+	ID:          "unit-test-policy-id",
+	Name:        "org-managed-policy",
+	Description: "managed by the security team",
+	Version:     "v2",
+	PolicyData: map[string]any{
+		"permissions": map[string]any{
+			"ssh": map[string]any{
+				"shell": map[string]any{},
+			},
+		},
+		"condition": map[string]any{
+			"who": map[string]any{
+				"email":           []any{"johndoe@example.com"},
+				"group":           []any{},
+				"service_account": nil,
+			},
+		},
+	},
+	SocketIDs: []string{"unit-test-socket-id-2", "unit-test-socket-id-1"},
+	TagRules:  []map[string]string{{"env": "prod"}},
+}
+
+func Test_DataSource_Policy_ByName(t *testing.T) {
+	config := `
+		data "border0_policy" "unit_test" {
+			name = "org-managed-policy"
+		}`
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// refresh for startup
+		clientMock.EXPECT().PoliciesByNames(matchContext, "org-managed-policy").Return([]border0client.Policy{mockOrgManagedPolicy}, nil).Call,
+
+		// refresh for apply, apply, and post-apply
+		clientMock.EXPECT().PoliciesByNames(matchContext, "org-managed-policy").Return([]border0client.Policy{mockOrgManagedPolicy}, nil).Call,
+		clientMock.EXPECT().PoliciesByNames(matchContext, "org-managed-policy").Return([]border0client.Policy{mockOrgManagedPolicy}, nil).Call,
+		clientMock.EXPECT().PoliciesByNames(matchContext, "org-managed-policy").Return([]border0client.Policy{mockOrgManagedPolicy}, nil).Call,
+
+		// refresh for cleanup
+		clientMock.EXPECT().PoliciesByNames(matchContext, "org-managed-policy").Return([]border0client.Policy{mockOrgManagedPolicy}, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "id", "unit-test-policy-id"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "name", "org-managed-policy"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "description", "managed by the security team"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "version", "v2"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "policy_data", `{"condition":{"who":{"email":["johndoe@example.com"]}},"permissions":{"ssh":{"shell":{}}}}`),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "org_wide", "false"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "tag_rules.#", "1"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "tag_rules.0.env", "prod"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "socket_ids.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "socket_ids.0", "unit-test-socket-id-1"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "socket_ids.1", "unit-test-socket-id-2"),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_Policy_ByID(t *testing.T) {
+	config := `
+		data "border0_policy" "unit_test" {
+			id = "unit-test-policy-id"
+		}`
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// refresh for startup
+		clientMock.EXPECT().Policy(matchContext, "unit-test-policy-id").Return(&mockOrgManagedPolicy, nil).Call,
+
+		// refresh for apply, apply, and post-apply
+		clientMock.EXPECT().Policy(matchContext, "unit-test-policy-id").Return(&mockOrgManagedPolicy, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-policy-id").Return(&mockOrgManagedPolicy, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-policy-id").Return(&mockOrgManagedPolicy, nil).Call,
+
+		// refresh for cleanup
+		clientMock.EXPECT().Policy(matchContext, "unit-test-policy-id").Return(&mockOrgManagedPolicy, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "id", "unit-test-policy-id"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "name", "org-managed-policy"),
+					resource.TestCheckResourceAttr("data.border0_policy.unit_test", "socket_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
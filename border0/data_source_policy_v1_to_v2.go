@@ -0,0 +1,63 @@
+package border0
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/policyutil"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePolicyV1ToV2() *schema.Resource {
+	return &schema.Resource{
+		Description: "`border0_policy_v1_to_v2` data source can be used to convert a v1 policy document, e.g. from the deprecated `border0_policy_document`, into the equivalent v2 policy document. Each v1 action maps to the v2 permission of the same socket type with full access, and the `who`, `where` and `when` conditions are carried over. Anything without an exact v2 mapping is reported in `lossy_mappings`.",
+		ReadContext: dataSourcePolicyV1ToV2Read,
+		Schema: map[string]*schema.Schema{
+			"policy_data": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The v1 policy document to convert, in JSON format.",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The converted v2 policy document, in JSON format.",
+			},
+			"lossy_mappings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The parts of the v1 policy document that have no exact v2 mapping and were dropped, e.g. `who.domain`.",
+			},
+		},
+	}
+}
+
+func dataSourcePolicyV1ToV2Read(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	document := d.Get("policy_data").(string)
+
+	var policyData border0client.PolicyData
+	if err := json.Unmarshal([]byte(document), &policyData); err != nil {
+		return diagnostics.Error(err, "Failed to unmarshal policy data")
+	}
+
+	converted, lossyMappings := policyutil.ConvertV1ToV2(policyData)
+
+	jsonDoc, err := normalizePolicyData(converted)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to process converted policy data")
+	}
+
+	d.SetId(strconv.Itoa(stringHashcode(document)))
+	return schemautil.SetValues(d, map[string]any{
+		"json":           jsonDoc,
+		"lossy_mappings": lossyMappings,
+	})
+}
@@ -0,0 +1,59 @@
+package border0_test
+
+import (
+	"testing"
+
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_DataSource_PolicyV1ToV2(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: policyDocumentConfig + `
+data "border0_policy_v1_to_v2" "unit_test" {
+  policy_data = data.border0_policy_document.unit_test.json
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testMatchResourceAttrJSON("data.border0_policy_v1_to_v2.unit_test", "json", `{
+						"permissions": {
+							"database": {},
+							"http": {},
+							"ssh": {
+								"docker_exec": {},
+								"exec": {},
+								"kubectl_exec": {},
+								"sftp": {},
+								"shell": {},
+								"tcp_forwarding": {}
+							},
+							"tls": {}
+						},
+						"condition": {
+							"who": {
+								"email": [ "johndoe@example.com" ],
+								"group": [ "db5c2352-b689-4135-babc-e97a8893128b" ]
+							},
+							"where": {
+								"allowed_ip": [ "0.0.0.0/0", "::/0" ],
+								"country": [ "BR", "CA", "FR", "NL", "US" ],
+								"country_not": [ "BE" ]
+							},
+							"when": {
+								"after": "2022-10-13T05:12:27Z",
+								"time_of_day_after": "00:00 UTC",
+								"time_of_day_before": "23:59 UTC"
+							}
+						}
+					}`),
+					resource.TestCheckResourceAttr("data.border0_policy_v1_to_v2.unit_test", "lossy_mappings.#", "1"),
+					resource.TestCheckResourceAttr("data.border0_policy_v1_to_v2.unit_test", "lossy_mappings.0", `who.domain ["example.com"] has no v2 equivalent and was dropped, list the users in who.email or add them to a group in who.group instead`),
+				),
+			},
+		},
+	})
+}
@@ -3,12 +3,18 @@ package border0
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/policyutil"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourcePolicyV2Document() *schema.Resource {
@@ -20,14 +26,52 @@ func dataSourcePolicyV2Document() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"resolved_group_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The group uuids that `condition.who.group_name` resolved to, keyed by group name.",
+			},
+			"resolved_user_emails": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The email addresses that `condition.who.user_id` resolved to, keyed by user ID.",
+			},
+			"lint_warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Warnings about risky, overly broad access granted by the document, e.g. ssh exec allowed without a commands list. Set the provider's `policy_lint_warnings_as_errors` to fail instead.",
+			},
+			"source_policy_documents": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "List of v2 policy documents (JSON) that are merged together, in order, to form the base of this document. Permissions and conditions declared in this document are merged on top of them.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+			},
+			"override_policy_documents": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "List of v2 policy documents (JSON) that are merged, in order, on top of this document. Allowed lists, including `allowed_ip` and `country`, are unioned, where a document that leaves one unset allows everything, `who` and `country_not` are unioned, so a country in any `country_not` is denied by the merged document, scalars such as `max_session_duration_seconds` and `when` times are taken from the last document that sets them.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+			},
 			"permissions": {
 				Type:        schema.TypeSet,
-				Required:    true,
+				MaxItems:    1,
+				Optional:    true,
 				Description: "The permissions that you want to allow.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"database": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The Database permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -79,6 +123,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"ssh": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The SSH permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -90,6 +135,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 									},
 									"shell": {
 										Type:        schema.TypeSet,
+										MaxItems:    1,
 										Optional:    true,
 										Description: "SSH Shell permission.",
 										Elem: &schema.Resource{
@@ -104,6 +150,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 									},
 									"exec": {
 										Type:        schema.TypeSet,
+										MaxItems:    1,
 										Optional:    true,
 										Description: "SSH Exec permission.",
 										Elem: &schema.Resource{
@@ -131,6 +178,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 									},
 									"sftp": {
 										Type:        schema.TypeSet,
+										MaxItems:    1,
 										Optional:    true,
 										Description: "SSH SFTP permission.",
 										Elem: &schema.Resource{
@@ -145,6 +193,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 									},
 									"tcp_forwarding": {
 										Type:        schema.TypeSet,
+										MaxItems:    1,
 										Optional:    true,
 										Description: "SSH TCP Forwarding permission.",
 										Elem: &schema.Resource{
@@ -183,6 +232,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 									},
 									"kubectl_exec": {
 										Type:        schema.TypeSet,
+										MaxItems:    1,
 										Optional:    true,
 										Description: "SSH Kubectl Exec permission.",
 										Elem: &schema.Resource{
@@ -229,6 +279,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 									},
 									"docker_exec": {
 										Type:        schema.TypeSet,
+										MaxItems:    1,
 										Optional:    true,
 										Description: "SSH Docker Exec permission.",
 										Elem: &schema.Resource{
@@ -277,6 +328,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"http": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The HTTP permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -291,6 +343,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"kubernetes": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The Kubernetes permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -305,6 +358,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"tls": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The TLS permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -319,6 +373,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"vnc": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The VNC permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -333,6 +388,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"rdp": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The RDP permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -347,6 +403,7 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"network": {
 							Type:        schema.TypeSet,
+							MaxItems:    1,
 							Optional:    true,
 							Description: "The Network permissions that you want to allow.",
 							Elem: &schema.Resource{
@@ -364,11 +421,13 @@ func dataSourcePolicyV2Document() *schema.Resource {
 			},
 			"condition": {
 				Type:     schema.TypeSet,
-				Required: true,
+				MaxItems: 1,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"who": {
 							Type:     schema.TypeSet,
+							MaxItems: 1,
 							Required: true,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
@@ -390,30 +449,52 @@ func dataSourcePolicyV2Document() *schema.Resource {
 										Optional:    true,
 										Description: "The service account name which is allowed to perform the actions.",
 									},
+									"group_name": {
+										Type:        schema.TypeSet,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Optional:    true,
+										Description: "The name of the group which is allowed to perform the actions. Names are resolved to group uuids through the Border0 API when the document is read, and added to `group`.",
+									},
+									"user_id": {
+										Type:        schema.TypeSet,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Optional:    true,
+										Description: "The ID of the user who is allowed to perform the actions. IDs are resolved to email addresses through the Border0 API when the document is read, and added to `email`.",
+									},
 								},
 							},
 							Description: "Who is allowed to perform the actions.",
 						},
 						"where": {
 							Type:     schema.TypeSet,
+							MaxItems: 1,
 							Required: true,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"allowed_ip": {
-										Type:        schema.TypeSet,
-										Elem:        &schema.Schema{Type: schema.TypeString},
+										Type: schema.TypeSet,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validatePolicyValue(policyutil.ValidateIPOrCIDR),
+										},
 										Optional:    true,
 										Description: "The IP address that the request must originate from to be allowed to perform the actions.",
 									},
 									"country": {
-										Type:        schema.TypeSet,
-										Elem:        &schema.Schema{Type: schema.TypeString},
+										Type: schema.TypeSet,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validatePolicyValue(policyutil.ValidateCountryCode),
+										},
 										Optional:    true,
 										Description: "The country that the request must originate from to be allowed to perform the actions.",
 									},
 									"country_not": {
-										Type:        schema.TypeSet,
-										Elem:        &schema.Schema{Type: schema.TypeString},
+										Type: schema.TypeSet,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validatePolicyValue(policyutil.ValidateCountryCode),
+										},
 										Optional:    true,
 										Description: "The country that the request must _NOT_ originate from to be allowed to perform the actions.",
 									},
@@ -423,28 +504,33 @@ func dataSourcePolicyV2Document() *schema.Resource {
 						},
 						"when": {
 							Type:     schema.TypeSet,
+							MaxItems: 1,
 							Required: true,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"after": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "When the request must be made after to be allowed to perform the actions.",
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validatePolicyValue(policyutil.ValidateTimestamp),
+										Description:  "When the request must be made after to be allowed to perform the actions.",
 									},
 									"before": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "When the request must be made before to be allowed to perform the actions.",
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validatePolicyValue(policyutil.ValidateTimestamp),
+										Description:  "When the request must be made before to be allowed to perform the actions.",
 									},
 									"time_of_day_after": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "When the request must be made after to be allowed to perform the actions.",
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validatePolicyValue(policyutil.ValidateTimeOfDay),
+										Description:  "When the request must be made after to be allowed to perform the actions.",
 									},
 									"time_of_day_before": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "When the request must be made before to be allowed to perform the actions.",
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validatePolicyValue(policyutil.ValidateTimeOfDay),
+										Description:  "When the request must be made before to be allowed to perform the actions.",
 									},
 								},
 							},
@@ -459,141 +545,353 @@ func dataSourcePolicyV2Document() *schema.Resource {
 }
 
 func dataSourcePolicyV2DocumentRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	policyData := expandPolicyV2Document(d.Get("permissions"), d.Get("condition"))
+
+	groupNames, userIDs := policyV2WhoReferences(d.Get("condition"))
+	resolvedGroupIDs, resolvedUserEmails, diags := resolvePolicyV2WhoReferences(ctx, m, groupNames, userIDs)
+	if diags.HasError() {
+		return diags
+	}
+	policyData.Condition.Who.Group = appendMissing(policyData.Condition.Who.Group, resolvedGroupIDs)
+	policyData.Condition.Who.Email = appendMissing(policyData.Condition.Who.Email, resolvedUserEmails)
+
+	sources, diags := decodePolicyV2Documents(d.Get("source_policy_documents").([]any), "source_policy_documents")
+	if diags.HasError() {
+		return diags
+	}
+	overrides, diags := decodePolicyV2Documents(d.Get("override_policy_documents").([]any), "override_policy_documents")
+	if diags.HasError() {
+		return diags
+	}
+	docs := append(append(sources, policyData), overrides...)
+	policyData = policyutil.MergeV2(docs...)
+
+	// the individual values are validated in the schema as well, but values coming
+	// from source and override documents, and contradictions, are only caught here
+	if errs := policyutil.ValidateCondition(policyData.Condition.Where, policyData.Condition.When); len(errs) > 0 {
+		return policyConditionDiagnostics(errs)
+	}
+
+	lintWarnings := policyutil.LintV2(policyData, false)
+	lintDiags := policyLintDiagnostics(lintWarnings, policyLintWarningsAsErrors(m))
+	if lintDiags.HasError() {
+		return lintDiags
+	}
+
+	jsonPolicyData, err := json.MarshalIndent(policyData, "", "  ")
+	if err != nil {
+		return diagnostics.Error(err, "Failed to marshal policy data")
+	}
+	jsonString := string(jsonPolicyData)
+
+	d.Set("json", jsonString)
+	d.Set("lint_warnings", lintWarnings)
+	d.Set("resolved_group_ids", resolvedGroupIDs)
+	d.Set("resolved_user_emails", resolvedUserEmails)
+	d.SetId(strconv.Itoa(stringHashcode(jsonString)))
+	return lintDiags
+}
+
+// validatePolicyValue adapts a policyutil validation function to a schema validation function.
+// Empty values are left alone, they are treated as not set.
+func validatePolicyValue(validate func(string) error) schema.SchemaValidateFunc {
+	return func(v any, k string) ([]string, []error) {
+		value, ok := v.(string)
+		if !ok || value == "" {
+			return nil, nil
+		}
+		if err := validate(value); err != nil {
+			return nil, []error{fmt.Errorf("%s: %w", k, err)}
+		}
+		return nil, nil
+	}
+}
+
+func policyConditionDiagnostics(errs []error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid policy condition",
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+// policyLintDiagnostics reports lint warnings as warning diagnostics, or as errors when asErrors is set.
+func policyLintDiagnostics(warnings []string, asErrors bool) diag.Diagnostics {
+	severity := diag.Warning
+	if asErrors {
+		severity = diag.Error
+	}
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  "Policy lint warning",
+			Detail:   warning,
+		})
+	}
+	return diags
+}
+
+// expandPolicyV2Document builds a v2 policy from the permissions and condition blocks of
+// border0_policy_v2_document, the blocks may be either sets or lists, see schemaList.
+func expandPolicyV2Document(permissionsBlock, conditionBlock any) border0client.PolicyDataV2 {
 	var policyData border0client.PolicyDataV2
 
-	if v, ok := d.GetOk("permissions"); ok {
-		if permissions := v.(*schema.Set).List(); len(permissions) > 0 {
-			permMap := permissions[0].(map[string]any)
+	if permissions := schemaList(permissionsBlock); len(permissions) > 0 {
+		permMap, _ := permissions[0].(map[string]any)
 
-			if v, ok := permMap["database"]; ok {
-				policyData.Permissions.Database = parseDatabasePermissions(v.(*schema.Set).List())
-			}
-			if v, ok := permMap["ssh"]; ok {
-				policyData.Permissions.SSH = parseSSHPermissions(v.(*schema.Set).List())
-			}
-			if v, ok := permMap["http"]; ok {
-				if httpPerms := v.(*schema.Set).List(); len(httpPerms) > 0 {
-					httpPerm := httpPerms[0].(map[string]any)
-					if v, ok := httpPerm["allowed"]; ok {
-						if allowed, ok := v.(bool); ok && allowed {
-							policyData.Permissions.HTTP = &border0client.HTTPPermissions{}
-						}
+		if v, ok := permMap["database"]; ok {
+			policyData.Permissions.Database = parseDatabasePermissions(schemaList(v))
+		}
+		if v, ok := permMap["ssh"]; ok {
+			policyData.Permissions.SSH = parseSSHPermissions(schemaList(v))
+		}
+		if v, ok := permMap["http"]; ok {
+			if httpPerms := schemaList(v); len(httpPerms) > 0 {
+				httpPerm, _ := httpPerms[0].(map[string]any)
+				if v, ok := httpPerm["allowed"]; ok {
+					if allowed, ok := v.(bool); ok && allowed {
+						policyData.Permissions.HTTP = &border0client.HTTPPermissions{}
 					}
 				}
 			}
-			if v, ok := permMap["kubernetes"]; ok {
-				if kubernetesPerms := v.(*schema.Set).List(); len(kubernetesPerms) > 0 {
-					kubernetesPerm := kubernetesPerms[0].(map[string]any)
-					if v, ok := kubernetesPerm["allowed"]; ok {
-						if allowed, ok := v.(bool); ok && allowed {
-							policyData.Permissions.Kubernetes = &border0client.KubernetesPermissions{}
-						}
+		}
+		if v, ok := permMap["kubernetes"]; ok {
+			if kubernetesPerms := schemaList(v); len(kubernetesPerms) > 0 {
+				kubernetesPerm, _ := kubernetesPerms[0].(map[string]any)
+				if v, ok := kubernetesPerm["allowed"]; ok {
+					if allowed, ok := v.(bool); ok && allowed {
+						policyData.Permissions.Kubernetes = &border0client.KubernetesPermissions{}
 					}
 				}
 			}
-			if v, ok := permMap["tls"]; ok {
-				if tlsPerms := v.(*schema.Set).List(); len(tlsPerms) > 0 {
-					tlsPerm := tlsPerms[0].(map[string]any)
-					if v, ok := tlsPerm["allowed"]; ok {
-						if allowed, ok := v.(bool); ok && allowed {
-							policyData.Permissions.TLS = &border0client.TLSPermissions{}
-						}
+		}
+		if v, ok := permMap["tls"]; ok {
+			if tlsPerms := schemaList(v); len(tlsPerms) > 0 {
+				tlsPerm, _ := tlsPerms[0].(map[string]any)
+				if v, ok := tlsPerm["allowed"]; ok {
+					if allowed, ok := v.(bool); ok && allowed {
+						policyData.Permissions.TLS = &border0client.TLSPermissions{}
 					}
 				}
 			}
-			if v, ok := permMap["vnc"]; ok {
-				if vncPerms := v.(*schema.Set).List(); len(vncPerms) > 0 {
-					vncPerm := vncPerms[0].(map[string]any)
-					if v, ok := vncPerm["allowed"]; ok {
-						if allowed, ok := v.(bool); ok && allowed {
-							policyData.Permissions.VNC = &border0client.VNCPermissions{}
-						}
+		}
+		if v, ok := permMap["vnc"]; ok {
+			if vncPerms := schemaList(v); len(vncPerms) > 0 {
+				vncPerm, _ := vncPerms[0].(map[string]any)
+				if v, ok := vncPerm["allowed"]; ok {
+					if allowed, ok := v.(bool); ok && allowed {
+						policyData.Permissions.VNC = &border0client.VNCPermissions{}
 					}
 				}
 			}
-			if v, ok := permMap["rdp"]; ok {
-				if rdpPerms := v.(*schema.Set).List(); len(rdpPerms) > 0 {
-					rdpPerm := rdpPerms[0].(map[string]any)
-					if v, ok := rdpPerm["allowed"]; ok {
-						if allowed, ok := v.(bool); ok && allowed {
-							policyData.Permissions.RDP = &border0client.RDPPermissions{}
-						}
+		}
+		if v, ok := permMap["rdp"]; ok {
+			if rdpPerms := schemaList(v); len(rdpPerms) > 0 {
+				rdpPerm, _ := rdpPerms[0].(map[string]any)
+				if v, ok := rdpPerm["allowed"]; ok {
+					if allowed, ok := v.(bool); ok && allowed {
+						policyData.Permissions.RDP = &border0client.RDPPermissions{}
 					}
 				}
 			}
-			if v, ok := permMap["network"]; ok {
-				if networkPerms := v.(*schema.Set).List(); len(networkPerms) > 0 {
-					networkPerm := networkPerms[0].(map[string]any)
-					if v, ok := networkPerm["allowed"]; ok {
-						if allowed, ok := v.(bool); ok && allowed {
-							policyData.Permissions.Network = &border0client.NetworkPermissions{}
-						}
+		}
+		if v, ok := permMap["network"]; ok {
+			if networkPerms := schemaList(v); len(networkPerms) > 0 {
+				networkPerm, _ := networkPerms[0].(map[string]any)
+				if v, ok := networkPerm["allowed"]; ok {
+					if allowed, ok := v.(bool); ok && allowed {
+						policyData.Permissions.Network = &border0client.NetworkPermissions{}
 					}
 				}
 			}
 		}
 	}
 
-	if v, ok := d.GetOk("condition"); ok {
-		if conditions := v.(*schema.Set).List(); len(conditions) > 0 {
-			condition := conditions[0].(map[string]any)
-			if v, ok := condition["who"]; ok {
-				if whos := v.(*schema.Set).List(); len(whos) > 0 {
-					who := whos[0].(map[string]any)
-					if v, ok := who["email"]; ok {
-						policyData.Condition.Who.Email = policyDecodeStringList(v.(*schema.Set).List())
-					}
-					if v, ok := who["group"]; ok {
-						policyData.Condition.Who.Group = policyDecodeStringList(v.(*schema.Set).List())
-					}
-					if v, ok := who["service_account"]; ok {
-						policyData.Condition.Who.ServiceAccount = policyDecodeStringList(v.(*schema.Set).List())
-					}
+	if conditions := schemaList(conditionBlock); len(conditions) > 0 {
+		condition, _ := conditions[0].(map[string]any)
+		if v, ok := condition["who"]; ok {
+			if whos := schemaList(v); len(whos) > 0 {
+				who, _ := whos[0].(map[string]any)
+				if v, ok := who["email"]; ok {
+					policyData.Condition.Who.Email = policyDecodeStringList(schemaList(v))
+				}
+				if v, ok := who["group"]; ok {
+					policyData.Condition.Who.Group = policyDecodeStringList(schemaList(v))
+				}
+				if v, ok := who["service_account"]; ok {
+					policyData.Condition.Who.ServiceAccount = policyDecodeStringList(schemaList(v))
 				}
 			}
-			if v, ok := condition["where"]; ok {
-				if wheres := v.(*schema.Set).List(); len(wheres) > 0 {
-					where := wheres[0].(map[string]any)
-					if v, ok := where["allowed_ip"]; ok {
-						policyData.Condition.Where.AllowedIP = policyDecodeStringList(v.(*schema.Set).List())
-					}
-					if v, ok := where["country"]; ok {
-						policyData.Condition.Where.Country = policyDecodeStringList(v.(*schema.Set).List())
-					}
-					if v, ok := where["country_not"]; ok {
-						policyData.Condition.Where.CountryNot = policyDecodeStringList(v.(*schema.Set).List())
-					}
+		}
+		if v, ok := condition["where"]; ok {
+			if wheres := schemaList(v); len(wheres) > 0 {
+				where, _ := wheres[0].(map[string]any)
+				if v, ok := where["allowed_ip"]; ok {
+					policyData.Condition.Where.AllowedIP = policyDecodeStringList(schemaList(v))
+				}
+				if v, ok := where["country"]; ok {
+					policyData.Condition.Where.Country = policyDecodeStringList(schemaList(v))
+				}
+				if v, ok := where["country_not"]; ok {
+					policyData.Condition.Where.CountryNot = policyDecodeStringList(schemaList(v))
 				}
 			}
-			if v, ok := condition["when"]; ok {
-				if whens := v.(*schema.Set).List(); len(whens) > 0 {
-					when := whens[0].(map[string]any)
-					if v, ok := when["after"]; ok {
-						policyData.Condition.When.After = v.(string)
-					}
-					if v, ok := when["before"]; ok {
-						policyData.Condition.When.Before = v.(string)
-					}
-					if v, ok := when["time_of_day_after"]; ok {
-						policyData.Condition.When.TimeOfDayAfter = v.(string)
-					}
-					if v, ok := when["time_of_day_before"]; ok {
-						policyData.Condition.When.TimeOfDayBefore = v.(string)
-					}
+		}
+		if v, ok := condition["when"]; ok {
+			if whens := schemaList(v); len(whens) > 0 {
+				when, _ := whens[0].(map[string]any)
+				if v, ok := when["after"]; ok {
+					policyData.Condition.When.After = v.(string)
+				}
+				if v, ok := when["before"]; ok {
+					policyData.Condition.When.Before = v.(string)
+				}
+				if v, ok := when["time_of_day_after"]; ok {
+					policyData.Condition.When.TimeOfDayAfter = v.(string)
+				}
+				if v, ok := when["time_of_day_before"]; ok {
+					policyData.Condition.When.TimeOfDayBefore = v.(string)
 				}
 			}
 		}
 	}
 
-	jsonPolicyData, err := json.MarshalIndent(policyData, "", "  ")
-	if err != nil {
-		return diagnostics.Error(err, "Failed to marshal policy data")
+	return policyData
+}
+
+// policyV2WhoReferences returns the group names and user IDs in the who block of the condition,
+// which are resolved through the api, see resolvePolicyV2WhoReferences.
+func policyV2WhoReferences(conditionBlock any) (groupNames, userIDs []string) {
+	conditions := schemaList(conditionBlock)
+	if len(conditions) == 0 {
+		return nil, nil
 	}
-	jsonString := string(jsonPolicyData)
+	condition, _ := conditions[0].(map[string]any)
+	whos := schemaList(condition["who"])
+	if len(whos) == 0 {
+		return nil, nil
+	}
+	who, _ := whos[0].(map[string]any)
+	return policyDecodeStringList(schemaList(who["group_name"])), policyDecodeStringList(schemaList(who["user_id"]))
+}
 
-	d.Set("json", jsonString)
-	d.SetId(strconv.Itoa(stringHashcode(jsonString)))
-	return nil
+// resolvePolicyV2WhoReferences looks up the group IDs for the given group names, and the emails for the
+// given user IDs. The api is only called when there is something to resolve. Names that match no group
+// or more than one group, and unknown user IDs, are reported as errors.
+func resolvePolicyV2WhoReferences(ctx context.Context, m any, groupNames, userIDs []string) (map[string]string, map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	resolvedGroupIDs := map[string]string{}
+	resolvedUserEmails := map[string]string{}
+
+	if len(groupNames) > 0 {
+		client := m.(border0client.Requester)
+
+		// NOTE: internally uses the border0 go sdk's groups paginator
+		// to retrieve all pages of groups in the organization, using
+		// the default page size defined there.
+		groups, err := client.Groups(ctx)
+		if err != nil {
+			return nil, nil, diagnostics.Error(err, "Failed to fetch groups list")
+		}
+
+		groupIDsByName := map[string][]string{}
+		for _, group := range groups.List {
+			groupIDsByName[group.DisplayName] = append(groupIDsByName[group.DisplayName], group.ID)
+		}
+		for _, name := range groupNames {
+			switch ids := groupIDsByName[name]; len(ids) {
+			case 0:
+				diags = append(diags, diag.Errorf("Group %q in condition.who.group_name not found", name)...)
+			case 1:
+				resolvedGroupIDs[name] = ids[0]
+			default:
+				sort.Strings(ids)
+				diags = append(diags, diag.Errorf("Group name %q in condition.who.group_name matches more than one group (%s), use condition.who.group with the group uuid instead", name, strings.Join(ids, ", "))...)
+			}
+		}
+	}
+
+	if len(userIDs) > 0 {
+		client := m.(border0client.Requester)
+
+		// NOTE: internally uses the border0 go sdk's users paginator
+		// to retrieve all pages of users in the organization, using
+		// the default page size defined there.
+		users, err := client.Users(ctx)
+		if err != nil {
+			return nil, nil, diagnostics.Error(err, "Failed to fetch users list")
+		}
+
+		emailsByID := map[string]string{}
+		for _, user := range users.List {
+			emailsByID[user.ID] = user.Email
+		}
+		for _, id := range userIDs {
+			email, ok := emailsByID[id]
+			if !ok {
+				diags = append(diags, diag.Errorf("User %q in condition.who.user_id not found", id)...)
+				continue
+			}
+			resolvedUserEmails[id] = email
+		}
+	}
+
+	return resolvedGroupIDs, resolvedUserEmails, diags
+}
+
+// appendMissing appends the values of the resolved map to the list, in sorted order, skipping values already in it.
+func appendMissing(list []string, resolved map[string]string) []string {
+	values := make([]string, 0, len(resolved))
+	for _, value := range resolved {
+		if !slices.Contains(list, value) && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return append(list, values...)
+}
+
+// documentOnlyWhoAttributes are resolved through the api when border0_policy_v2_document is read,
+// they are left out where its who block is reused.
+var documentOnlyWhoAttributes = []string{"group_name", "user_id"}
+
+// withoutDocumentOnlyWhoAttributes removes documentOnlyWhoAttributes from a copy of the condition schema.
+func withoutDocumentOnlyWhoAttributes(condition *schema.Schema) *schema.Schema {
+	who := condition.Elem.(*schema.Resource).Schema["who"].Elem.(*schema.Resource).Schema
+	for _, key := range documentOnlyWhoAttributes {
+		delete(who, key)
+	}
+	return condition
+}
+
+// schemaList returns the elements of a list or set value.
+func schemaList(v any) []any {
+	switch v := v.(type) {
+	case *schema.Set:
+		return v.List()
+	case []any:
+		return v
+	default:
+		return nil
+	}
+}
+
+func decodePolicyV2Documents(documents []any, attribute string) ([]border0client.PolicyDataV2, diag.Diagnostics) {
+	decoded := make([]border0client.PolicyDataV2, 0, len(documents))
+	for i, document := range documents {
+		var policyData border0client.PolicyDataV2
+		raw, _ := document.(string)
+		if err := json.Unmarshal([]byte(raw), &policyData); err != nil {
+			return nil, diagnostics.Error(err, "Failed to unmarshal %s[%d]", attribute, i)
+		}
+		decoded = append(decoded, policyData)
+	}
+	return decoded, nil
 }
 
 func parseDatabasePermissions(dbPerms []any) *border0client.DatabasePermissions {
@@ -602,7 +900,7 @@ func parseDatabasePermissions(dbPerms []any) *border0client.DatabasePermissions
 	var allowed bool
 
 	for _, dbPerm := range dbPerms {
-		permMap := dbPerm.(map[string]any)
+		permMap, _ := dbPerm.(map[string]any)
 
 		if v, ok := permMap["allowed"]; ok {
 			allowed = v.(bool)
@@ -613,7 +911,7 @@ func parseDatabasePermissions(dbPerms []any) *border0client.DatabasePermissions
 				if v, ok := permMap["allowed_databases"]; ok {
 					databases := []border0client.DatabasePermission{}
 					for _, ad := range v.([]any) {
-						adMap := ad.(map[string]any)
+						adMap, _ := ad.(map[string]any)
 						allowedDatabase := border0client.DatabasePermission{
 							Database: adMap["database"].(string),
 						}
@@ -667,15 +965,15 @@ func parseSSHPermissions(sshPerms []any) *border0client.SSHPermissions {
 	var allowed bool
 
 	for _, sshPerm := range sshPerms {
-		permMap := sshPerm.(map[string]any)
+		permMap, _ := sshPerm.(map[string]any)
 		if v, ok := permMap["allowed"]; ok {
 			allowed = v.(bool)
 		}
 
 		if v, ok := permMap["shell"]; ok {
 			var execAllowed bool
-			if shells := v.(*schema.Set).List(); len(shells) > 0 {
-				shell := shells[0].(map[string]any)
+			if shells := schemaList(v); len(shells) > 0 {
+				shell, _ := shells[0].(map[string]any)
 				if v, ok := shell["allowed"]; ok {
 					execAllowed, _ = v.(bool)
 				}
@@ -688,10 +986,10 @@ func parseSSHPermissions(sshPerms []any) *border0client.SSHPermissions {
 
 		if v, ok := permMap["exec"]; ok {
 			var useCommandList, execAllowed bool
-			var commands []string
+			commands := []string{}
 
-			if execs := v.(*schema.Set).List(); len(execs) > 0 {
-				exec := execs[0].(map[string]any)
+			if execs := schemaList(v); len(execs) > 0 {
+				exec, _ := execs[0].(map[string]any)
 				if v, ok := exec["allowed"]; ok {
 					execAllowed, _ = v.(bool)
 				}
@@ -718,8 +1016,8 @@ func parseSSHPermissions(sshPerms []any) *border0client.SSHPermissions {
 
 		if v, ok := permMap["sftp"]; ok {
 			var sftpAllowed bool
-			if sftps := v.(*schema.Set).List(); len(sftps) > 0 {
-				sftp := sftps[0].(map[string]any)
+			if sftps := schemaList(v); len(sftps) > 0 {
+				sftp, _ := sftps[0].(map[string]any)
 				if v, ok := sftp["allowed"]; ok {
 					sftpAllowed, _ = v.(bool)
 				}
@@ -733,8 +1031,8 @@ func parseSSHPermissions(sshPerms []any) *border0client.SSHPermissions {
 		if v, ok := permMap["tcp_forwarding"]; ok {
 			var tcpForwardingAllowed, useAllowedConnectionsList bool
 			var allowedConnections *[]border0client.SSHTcpForwardingConnection
-			if tcpForwardings := v.(*schema.Set).List(); len(tcpForwardings) > 0 {
-				tcpForwarding := tcpForwardings[0].(map[string]any)
+			if tcpForwardings := schemaList(v); len(tcpForwardings) > 0 {
+				tcpForwarding, _ := tcpForwardings[0].(map[string]any)
 				if v, ok := tcpForwarding["allowed"]; ok {
 					tcpForwardingAllowed, _ = v.(bool)
 				}
@@ -760,8 +1058,8 @@ func parseSSHPermissions(sshPerms []any) *border0client.SSHPermissions {
 		if v, ok := permMap["kubectl_exec"]; ok {
 			var kubectlExecAllowed, useAllowedNamespacesList bool
 			var allowedNamespaces *[]border0client.KubectlExecNamespace
-			if kubectlExecs := v.(*schema.Set).List(); len(kubectlExecs) > 0 {
-				kubectlExec := kubectlExecs[0].(map[string]any)
+			if kubectlExecs := schemaList(v); len(kubectlExecs) > 0 {
+				kubectlExec, _ := kubectlExecs[0].(map[string]any)
 				if v, ok := kubectlExec["allowed"]; ok {
 					kubectlExecAllowed, _ = v.(bool)
 				}
@@ -786,9 +1084,9 @@ func parseSSHPermissions(sshPerms []any) *border0client.SSHPermissions {
 
 		if v, ok := permMap["docker_exec"]; ok {
 			var dockerExecAllowed, useAllowedContainerList bool
-			var allowedContainers []string
-			if dockerExecs := v.(*schema.Set).List(); len(dockerExecs) > 0 {
-				dockerExec := dockerExecs[0].(map[string]any)
+			allowedContainers := []string{}
+			if dockerExecs := schemaList(v); len(dockerExecs) > 0 {
+				dockerExec, _ := dockerExecs[0].(map[string]any)
 				if v, ok := dockerExec["allowed"]; ok {
 					dockerExecAllowed, _ = v.(bool)
 				}
@@ -850,10 +1148,10 @@ func parseSSHPermissions(sshPerms []any) *border0client.SSHPermissions {
 }
 
 func parseSSHTCPForwardingConnections(allowedConnections []any) *[]border0client.SSHTcpForwardingConnection {
-	var connections []border0client.SSHTcpForwardingConnection
+	connections := []border0client.SSHTcpForwardingConnection{}
 
 	for _, conn := range allowedConnections {
-		connMap := conn.(map[string]any)
+		connMap, _ := conn.(map[string]any)
 
 		var destAddress, destPort string
 		if addr, ok := connMap["destination_address"]; ok && addr != nil {
@@ -874,10 +1172,10 @@ func parseSSHTCPForwardingConnections(allowedConnections []any) *[]border0client
 }
 
 func parseSSHKubectlExecNamespaces(allowedNamespaces []any) *[]border0client.KubectlExecNamespace {
-	var namespaces []border0client.KubectlExecNamespace
+	namespaces := []border0client.KubectlExecNamespace{}
 
 	for _, ns := range allowedNamespaces {
-		nsMap := ns.(map[string]any)
+		nsMap, _ := ns.(map[string]any)
 		namespace := border0client.KubectlExecNamespace{
 			Namespace: nsMap["namespace"].(string),
 		}
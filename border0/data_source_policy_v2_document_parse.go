@@ -0,0 +1,247 @@
+package border0
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePolicyV2DocumentParse() *schema.Resource {
+	documentSchema := dataSourcePolicyV2Document().Schema
+
+	return &schema.Resource{
+		Description: "`border0_policy_v2_document_parse` data source can be used to parse a v2 policy document in JSON format into the same structured `permissions` and `condition` blocks that `border0_policy_v2_document` accepts.",
+		ReadContext: dataSourcePolicyV2DocumentParseRead,
+		Schema: map[string]*schema.Schema{
+			"json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The v2 policy document to parse, in JSON format.",
+			},
+			"permissions": computedSchema(documentSchema["permissions"]),
+			"condition":   withoutDocumentOnlyWhoAttributes(computedSchema(documentSchema["condition"])),
+		},
+	}
+}
+
+func dataSourcePolicyV2DocumentParseRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	document := d.Get("json").(string)
+
+	var policyData border0client.PolicyDataV2
+	if err := json.Unmarshal([]byte(document), &policyData); err != nil {
+		return diagnostics.Error(err, "Failed to unmarshal policy data")
+	}
+
+	d.SetId(strconv.Itoa(stringHashcode(document)))
+	return schemautil.SetValues(d, map[string]any{
+		"permissions": flattenPolicyV2Permissions(policyData.Permissions),
+		"condition":   flattenPolicyV2Condition(policyData.Condition),
+	})
+}
+
+// computedSchema returns a read-only copy of the given schema. Nested blocks are
+// turned into lists so they can be indexed from configuration.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Type:        s.Type,
+		Computed:    true,
+		Description: s.Description,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		if computed.Type == schema.TypeSet {
+			computed.Type = schema.TypeList
+		}
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = computedSchema(v)
+		}
+		computed.Elem = &schema.Resource{Schema: nested}
+	case *schema.Schema:
+		computed.Elem = &schema.Schema{Type: elem.Type}
+	}
+	return computed
+}
+
+// flattenPolicyV2Permissions is the inverse of the permissions parsing in dataSourcePolicyV2DocumentRead.
+func flattenPolicyV2Permissions(permissions border0client.PolicyPermissions) []any {
+	// permissions that are not allowed are set explicitly, so they are also cleared from existing state
+	flattened := map[string]any{
+		"database":   []any{},
+		"ssh":        []any{},
+		"http":       []any{},
+		"kubernetes": []any{},
+		"tls":        []any{},
+		"vnc":        []any{},
+		"rdp":        []any{},
+		"network":    []any{},
+	}
+
+	if db := permissions.Database; db != nil {
+		database := map[string]any{
+			"allowed":                      true,
+			"use_allowed_databases_list":   db.AllowedDatabases != nil,
+			"max_session_duration_seconds": derefInt(db.MaxSessionDurationSeconds),
+			"allowed_databases":            []any{},
+		}
+		if db.AllowedDatabases != nil {
+			allowedDatabases := make([]any, 0, len(*db.AllowedDatabases))
+			for _, allowedDatabase := range *db.AllowedDatabases {
+				allowedDatabases = append(allowedDatabases, map[string]any{
+					"database":                     allowedDatabase.Database,
+					"use_allowed_query_types_list": allowedDatabase.AllowedQueryTypes != nil,
+					"allowed_query_types":          derefStrings(allowedDatabase.AllowedQueryTypes),
+				})
+			}
+			database["allowed_databases"] = allowedDatabases
+		}
+		flattened["database"] = []any{database}
+	}
+
+	if ssh := permissions.SSH; ssh != nil {
+		flattened["ssh"] = []any{flattenSSHPermissions(ssh)}
+	}
+
+	for key, allowed := range map[string]bool{
+		"http":       permissions.HTTP != nil,
+		"kubernetes": permissions.Kubernetes != nil,
+		"tls":        permissions.TLS != nil,
+		"vnc":        permissions.VNC != nil,
+		"rdp":        permissions.RDP != nil,
+		"network":    permissions.Network != nil,
+	} {
+		if allowed {
+			flattened[key] = []any{map[string]any{"allowed": true}}
+		}
+	}
+
+	return []any{flattened}
+}
+
+func flattenSSHPermissions(ssh *border0client.SSHPermissions) map[string]any {
+	flattened := map[string]any{
+		"allowed":                      true,
+		"max_session_duration_seconds": derefInt(ssh.MaxSessionDurationSeconds),
+		"use_allowed_usernames_list":   ssh.AllowedUsernames != nil,
+		"allowed_usernames":            derefStrings(ssh.AllowedUsernames),
+		"shell":                        []any{},
+		"sftp":                         []any{},
+		"exec":                         []any{},
+		"tcp_forwarding":               []any{},
+		"kubectl_exec":                 []any{},
+		"docker_exec":                  []any{},
+	}
+
+	if ssh.Shell != nil {
+		flattened["shell"] = []any{map[string]any{"allowed": true}}
+	}
+	if ssh.SFTP != nil {
+		flattened["sftp"] = []any{map[string]any{"allowed": true}}
+	}
+	if ssh.Exec != nil {
+		flattened["exec"] = []any{map[string]any{
+			"allowed":           true,
+			"use_commands_list": ssh.Exec.Commands != nil,
+			"commands":          derefStrings(ssh.Exec.Commands),
+		}}
+	}
+	if ssh.TCPForwarding != nil {
+		connections := []any{}
+		if ssh.TCPForwarding.AllowedConnections != nil {
+			for _, connection := range *ssh.TCPForwarding.AllowedConnections {
+				connections = append(connections, map[string]any{
+					"destination_address": derefString(connection.DestinationAddress),
+					"destination_port":    derefString(connection.DestinationPort),
+				})
+			}
+		}
+		flattened["tcp_forwarding"] = []any{map[string]any{
+			"allowed":                      true,
+			"use_allowed_connections_list": ssh.TCPForwarding.AllowedConnections != nil,
+			"allowed_connections":          connections,
+		}}
+	}
+	if ssh.KubectlExec != nil {
+		namespaces := []any{}
+		if ssh.KubectlExec.AllowedNamespaces != nil {
+			for _, namespace := range *ssh.KubectlExec.AllowedNamespaces {
+				podSelector := map[string]any{}
+				if namespace.PodSelector != nil {
+					for key, value := range *namespace.PodSelector {
+						podSelector[key] = value
+					}
+				}
+				namespaces = append(namespaces, map[string]any{
+					"namespace":        namespace.Namespace,
+					"use_pod_selector": namespace.PodSelector != nil,
+					"pod_selector":     podSelector,
+				})
+			}
+		}
+		flattened["kubectl_exec"] = []any{map[string]any{
+			"allowed":                     true,
+			"use_allowed_namespaces_list": ssh.KubectlExec.AllowedNamespaces != nil,
+			"allowed_namespaces":          namespaces,
+		}}
+	}
+	if ssh.DockerExec != nil {
+		flattened["docker_exec"] = []any{map[string]any{
+			"allowed":                     true,
+			"use_allowed_containers_list": ssh.DockerExec.AllowedContainers != nil,
+			"allowed_containers":          derefStrings(ssh.DockerExec.AllowedContainers),
+		}}
+	}
+
+	return flattened
+}
+
+// flattenPolicyV2Condition is the inverse of the condition parsing in dataSourcePolicyV2DocumentRead.
+func flattenPolicyV2Condition(condition border0client.PolicyConditionV2) []any {
+	return []any{map[string]any{
+		"who": []any{map[string]any{
+			"email":           condition.Who.Email,
+			"group":           condition.Who.Group,
+			"service_account": condition.Who.ServiceAccount,
+		}},
+		"where": []any{map[string]any{
+			"allowed_ip":  condition.Where.AllowedIP,
+			"country":     condition.Where.Country,
+			"country_not": condition.Where.CountryNot,
+		}},
+		"when": []any{map[string]any{
+			"after":              condition.When.After,
+			"before":             condition.When.Before,
+			"time_of_day_after":  condition.When.TimeOfDayAfter,
+			"time_of_day_before": condition.When.TimeOfDayBefore,
+		}},
+	}}
+}
+
+func derefInt(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func derefString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func derefStrings(v *[]string) []string {
+	if v == nil {
+		return []string{}
+	}
+	return *v
+}
@@ -0,0 +1,327 @@
+package border0_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/border0"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var policyDocumentV2ParseConfig = `
+data "border0_policy_v2_document_parse" "unit_test" {
+	json = jsonencode({
+		"permissions" : {
+			"ssh" : {
+				"shell" : {},
+				"exec" : { "commands" : [ "ls" ] },
+				"max_session_duration_seconds" : 3600
+			},
+			"http" : {}
+		},
+		"condition" : {
+			"who" : {
+				"email" : [ "johndoe@example.com" ],
+				"group" : [],
+				"service_account" : []
+			},
+			"where" : {
+				"allowed_ip" : [ "10.0.0.0/8" ]
+			},
+			"when" : {
+				"time_of_day_after" : "08:00 UTC"
+			}
+		}
+	})
+}
+`
+
+func Test_DataSource_PolicyDocumentV2Parse(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: policyDocumentV2ParseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.ssh.0.allowed", "true"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.ssh.0.max_session_duration_seconds", "3600"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.ssh.0.shell.0.allowed", "true"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.ssh.0.exec.0.use_commands_list", "true"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.ssh.0.exec.0.commands.0", "ls"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.ssh.0.sftp.#", "0"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.http.0.allowed", "true"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "permissions.0.database.#", "0"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "condition.0.who.0.email.0", "johndoe@example.com"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "condition.0.where.0.allowed_ip.0", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document_parse.unit_test", "condition.0.when.0.time_of_day_after", "08:00 UTC"),
+				),
+			},
+		},
+	})
+}
+
+// Test_DataSource_PolicyDocumentV2Parse_RoundTrip checks that, for randomly generated policy
+// documents, parsing a document and feeding the result back into border0_policy_v2_document
+// yields the original document.
+func Test_DataSource_PolicyDocumentV2Parse_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	provider := border0.Provider()
+	document := provider.DataSourcesMap["border0_policy_v2_document"]
+	parse := provider.DataSourcesMap["border0_policy_v2_document_parse"]
+
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 500; i++ {
+		original := randomPolicyDataV2(rng)
+		originalJSON, err := json.Marshal(original)
+		require.NoError(t, err)
+
+		parsed := schema.TestResourceDataRaw(t, parse.Schema, map[string]any{"json": string(originalJSON)})
+		require.False(t, parse.ReadContext(ctx, parsed, nil).HasError())
+
+		built := schema.TestResourceDataRaw(t, document.Schema, map[string]any{
+			"permissions": toRawConfig(parsed.Get("permissions")),
+			"condition":   toRawConfig(parsed.Get("condition")),
+		})
+		require.False(t, document.ReadContext(ctx, built, nil).HasError())
+
+		var roundTripped border0client.PolicyDataV2
+		require.NoError(t, json.Unmarshal([]byte(built.Get("json").(string)), &roundTripped))
+
+		require.Equal(t, canonicalPolicyDataV2(original), canonicalPolicyDataV2(roundTripped), "document: %s", originalJSON)
+	}
+}
+
+// toRawConfig converts values read from schema.ResourceData back into the shape of raw configuration.
+func toRawConfig(v any) any {
+	switch x := v.(type) {
+	case *schema.Set:
+		return toRawConfig(x.List())
+	case []any:
+		raw := make([]any, 0, len(x))
+		for _, e := range x {
+			raw = append(raw, toRawConfig(e))
+		}
+		return raw
+	case map[string]any:
+		raw := make(map[string]any, len(x))
+		for k, e := range x {
+			raw[k] = toRawConfig(e)
+		}
+		return raw
+	default:
+		return v
+	}
+}
+
+// canonicalPolicyDataV2 accounts for condition lists being sets in border0_policy_v2_document:
+// their order and duplicates are not significant, and unset lists are equivalent to empty ones.
+func canonicalPolicyDataV2(policyData border0client.PolicyDataV2) border0client.PolicyDataV2 {
+	canonical := func(values []string) []string {
+		seen := map[string]bool{}
+		out := []string{}
+		for _, v := range values {
+			if !seen[v] {
+				seen[v] = true
+				out = append(out, v)
+			}
+		}
+		sort.Strings(out)
+		return out
+	}
+	policyData.Condition.Who.Email = canonical(policyData.Condition.Who.Email)
+	policyData.Condition.Who.Group = canonical(policyData.Condition.Who.Group)
+	policyData.Condition.Who.ServiceAccount = canonical(policyData.Condition.Who.ServiceAccount)
+	policyData.Condition.Where.AllowedIP = canonical(policyData.Condition.Where.AllowedIP)
+	policyData.Condition.Where.Country = canonical(policyData.Condition.Where.Country)
+	policyData.Condition.Where.CountryNot = canonical(policyData.Condition.Where.CountryNot)
+	return policyData
+}
+
+func randomPolicyDataV2(rng *rand.Rand) border0client.PolicyDataV2 {
+	maybe := func() bool { return rng.Intn(2) == 0 }
+	words := func(prefix string) []string {
+		n := rng.Intn(4)
+		values := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			values = append(values, fmt.Sprintf("%s-%d", prefix, rng.Intn(5)))
+		}
+		return values
+	}
+	allowList := func(prefix string) *[]string {
+		if maybe() {
+			return nil
+		}
+		values := words(prefix)
+		return &values
+	}
+	duration := func() *int {
+		if maybe() {
+			return nil
+		}
+		d := 1 + rng.Intn(86400)
+		return &d
+	}
+	timestamp := func() string {
+		if maybe() {
+			return ""
+		}
+		return fmt.Sprintf("2024-%02d-%02dT%02d:00:00Z", 1+rng.Intn(12), 1+rng.Intn(28), rng.Intn(24))
+	}
+	timeOfDay := func() string {
+		if maybe() {
+			return ""
+		}
+		return fmt.Sprintf("%02d:%02d UTC", rng.Intn(24), rng.Intn(60))
+	}
+
+	var policyData border0client.PolicyDataV2
+
+	if maybe() {
+		database := &border0client.DatabasePermissions{MaxSessionDurationSeconds: duration()}
+		if maybe() {
+			databases := []border0client.DatabasePermission{}
+			for _, name := range words("db") {
+				databases = append(databases, border0client.DatabasePermission{Database: name, AllowedQueryTypes: allowList("query")})
+			}
+			database.AllowedDatabases = &databases
+		}
+		policyData.Permissions.Database = database
+	}
+
+	if maybe() {
+		ssh := &border0client.SSHPermissions{
+			MaxSessionDurationSeconds: duration(),
+			AllowedUsernames:          allowList("user"),
+		}
+		if maybe() {
+			ssh.Shell = &border0client.SSHShellPermission{}
+		}
+		if maybe() {
+			ssh.SFTP = &border0client.SSHSFTPPermission{}
+		}
+		if maybe() {
+			ssh.Exec = &border0client.SSHExecPermission{Commands: allowList("cmd")}
+		}
+		if maybe() {
+			ssh.DockerExec = &border0client.SSHDockerExecPermission{AllowedContainers: allowList("container")}
+		}
+		if maybe() {
+			ssh.TCPForwarding = &border0client.SSHTCPForwardingPermission{}
+			if maybe() {
+				connections := []border0client.SSHTcpForwardingConnection{}
+				for _, host := range words("host") {
+					address, port := host, fmt.Sprint(1+rng.Intn(65535))
+					connections = append(connections, border0client.SSHTcpForwardingConnection{DestinationAddress: &address, DestinationPort: &port})
+				}
+				ssh.TCPForwarding.AllowedConnections = &connections
+			}
+		}
+		if maybe() {
+			ssh.KubectlExec = &border0client.SSHKubectlExecPermission{}
+			if maybe() {
+				namespaces := []border0client.KubectlExecNamespace{}
+				for _, name := range words("ns") {
+					namespace := border0client.KubectlExecNamespace{Namespace: name}
+					if maybe() {
+						podSelector := map[string]string{}
+						for _, label := range words("label") {
+							podSelector[label] = fmt.Sprint(rng.Intn(3))
+						}
+						namespace.PodSelector = &podSelector
+					}
+					namespaces = append(namespaces, namespace)
+				}
+				ssh.KubectlExec.AllowedNamespaces = &namespaces
+			}
+		}
+		policyData.Permissions.SSH = ssh
+	}
+
+	if maybe() {
+		policyData.Permissions.HTTP = &border0client.HTTPPermissions{}
+	}
+	if maybe() {
+		policyData.Permissions.Kubernetes = &border0client.KubernetesPermissions{}
+	}
+	if maybe() {
+		policyData.Permissions.TLS = &border0client.TLSPermissions{}
+	}
+	if maybe() {
+		policyData.Permissions.VNC = &border0client.VNCPermissions{}
+	}
+	if maybe() {
+		policyData.Permissions.RDP = &border0client.RDPPermissions{}
+	}
+	if maybe() {
+		policyData.Permissions.Network = &border0client.NetworkPermissions{}
+	}
+
+	policyData.Condition.Who = border0client.PolicyWhoV2{
+		Email:          words("user@example.com"),
+		Group:          words("group"),
+		ServiceAccount: words("sa"),
+	}
+	// conditions are validated, so only generate valid and non-contradicting values
+	pick := func(pool ...string) []string {
+		values := []string{}
+		for _, value := range pool {
+			if rng.Intn(3) == 0 {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	policyData.Condition.Where = border0client.PolicyWhere{
+		AllowedIP:  pick("0.0.0.0/0", "::/0", "10.0.0.0/8", "192.168.1.10", "2001:db8::/32"),
+		Country:    pick("NL", "CA", "US", "BR"),
+		CountryNot: pick("BE", "FR", "DE"),
+	}
+	after, before := timestamp(), timestamp()
+	if after != "" && before != "" && after >= before {
+		after, before = "", after
+	}
+	policyData.Condition.When = border0client.PolicyWhen{
+		After:           after,
+		Before:          before,
+		TimeOfDayAfter:  timeOfDay(),
+		TimeOfDayBefore: timeOfDay(),
+	}
+
+	return policyData
+}
+
+func Test_DataSource_PolicyDocumentV2Parse_EmptyAllowLists(t *testing.T) {
+	// an empty allow list (nothing allowed) must not be confused with an unset one (everything allowed)
+	ctx := context.Background()
+	provider := border0.Provider()
+	document := provider.DataSourcesMap["border0_policy_v2_document"]
+	parse := provider.DataSourcesMap["border0_policy_v2_document_parse"]
+
+	parsed := schema.TestResourceDataRaw(t, parse.Schema, map[string]any{
+		"json": `{"permissions":{"ssh":{"exec":{"commands":[]},"docker_exec":{}}},"condition":{}}`,
+	})
+	require.False(t, parse.ReadContext(ctx, parsed, nil).HasError())
+	assert.Equal(t, true, parsed.Get("permissions.0.ssh.0.exec.0.use_commands_list"))
+	assert.Equal(t, false, parsed.Get("permissions.0.ssh.0.docker_exec.0.use_allowed_containers_list"))
+
+	built := schema.TestResourceDataRaw(t, document.Schema, map[string]any{
+		"permissions": toRawConfig(parsed.Get("permissions")),
+		"condition":   toRawConfig(parsed.Get("condition")),
+	})
+	require.False(t, document.ReadContext(ctx, built, nil).HasError())
+
+	var roundTripped border0client.PolicyDataV2
+	require.NoError(t, json.Unmarshal([]byte(built.Get("json").(string)), &roundTripped))
+	assert.Equal(t, &[]string{}, roundTripped.Permissions.SSH.Exec.Commands)
+	assert.Nil(t, roundTripped.Permissions.SSH.DockerExec.AllowedContainers)
+}
@@ -1,10 +1,17 @@
 package border0_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/border0"
 	"github.com/borderzero/terraform-provider-border0/mocks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
 )
 
 var policyDocumentV2Config = `
@@ -176,3 +183,341 @@ func Test_DataSource_PolicyDocumentV2(t *testing.T) {
 		},
 	})
 }
+
+var policyDocumentV2ComposedConfig = `
+data "border0_policy_v2_document" "base" {
+	permissions {
+		ssh {
+			allowed = true
+			max_session_duration_seconds = 3600
+			shell {
+				allowed = true
+			}
+		}
+	}
+	condition {
+		who {
+			group = [ "db5c2352-b689-4135-babc-e97a8893128b" ]
+		}
+		where {
+			allowed_ip = [ "10.0.0.0/8" ]
+		}
+		when {
+			time_of_day_after = "08:00 UTC"
+			time_of_day_before = "18:00 UTC"
+		}
+	}
+}
+
+data "border0_policy_v2_document" "unit_test" {
+	source_policy_documents = [ data.border0_policy_v2_document.base.json ]
+
+	permissions {
+		http {
+			allowed = true
+		}
+	}
+	condition {
+		who {
+			email = [ "johndoe@example.com" ]
+		}
+		where {}
+		when {}
+	}
+
+	override_policy_documents = [
+		jsonencode({
+			"permissions" : { "ssh" : { "max_session_duration_seconds" : 600 } },
+			"condition" : { "when" : { "time_of_day_before" : "20:00 UTC" } }
+		})
+	]
+}
+`
+
+func Test_DataSource_PolicyDocumentV2_SourceAndOverrideDocuments(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: policyDocumentV2ComposedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.border0_policy_v2_document.unit_test", "json", func(value string) error {
+						maxSessionDurationSeconds := 600
+						var policyData border0client.PolicyDataV2
+						if err := json.Unmarshal([]byte(value), &policyData); err != nil {
+							return err
+						}
+						expected := border0client.PolicyDataV2{
+							Permissions: border0client.PolicyPermissions{
+								SSH: &border0client.SSHPermissions{
+									Shell:                     &border0client.SSHShellPermission{},
+									MaxSessionDurationSeconds: &maxSessionDurationSeconds,
+								},
+								HTTP: &border0client.HTTPPermissions{},
+							},
+							Condition: border0client.PolicyConditionV2{
+								Who: border0client.PolicyWhoV2{
+									Email:          []string{"johndoe@example.com"},
+									Group:          []string{"db5c2352-b689-4135-babc-e97a8893128b"},
+									ServiceAccount: []string{},
+								},
+								// the empty where block of this document allows from anywhere,
+								// so it absorbs the allowed_ip of the source document
+								Where: border0client.PolicyWhere{
+									AllowedIP:  []string{},
+									Country:    []string{},
+									CountryNot: []string{},
+								},
+								When: border0client.PolicyWhen{
+									TimeOfDayAfter:  "08:00 UTC",
+									TimeOfDayBefore: "20:00 UTC",
+								},
+							},
+						}
+						if !reflect.DeepEqual(expected, policyData) {
+							return fmt.Errorf("unexpected merged policy document: %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_PolicyDocumentV2_RejectsMultipleBlocks(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "border0_policy_v2_document" "unit_test" {
+						permissions {
+							http {
+								allowed = true
+							}
+						}
+						permissions {
+							ssh {
+								allowed = true
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`Too many permissions blocks`),
+			},
+			{
+				Config: `
+					data "border0_policy_v2_document" "unit_test" {
+						condition {
+							who {
+								email = [ "johndoe@example.com" ]
+							}
+							who {
+								email = [ "janedoe@example.com" ]
+							}
+							where {}
+							when {}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`Too many who blocks`),
+			},
+		},
+	})
+}
+
+func Test_DataSource_PolicyDocumentV2_InvalidCondition(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "border0_policy_v2_document" "unit_test" {
+						condition {
+							who {}
+							where {
+								country = [ "Netherlands" ]
+							}
+							when {
+								time_of_day_after = "8am"
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`"Netherlands" is not a valid ISO 3166-1 alpha-2 country code`),
+			},
+			{
+				Config: `
+					data "border0_policy_v2_document" "unit_test" {
+						source_policy_documents = [
+							jsonencode({ "condition" : { "where" : { "country_not" : [ "BE" ] } } })
+						]
+						condition {
+							who {}
+							where {
+								country = [ "BE" ]
+							}
+							when {}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`country "BE" is listed in both country and country_not`),
+			},
+		},
+	})
+}
+
+var lintedPolicyDocumentV2Config = `
+data "border0_policy_v2_document" "unit_test" {
+  permissions {
+    ssh {
+      max_session_duration_seconds = 3600
+      exec {}
+    }
+  }
+  condition {
+    who {
+      email = [ "johndoe@example.com" ]
+    }
+    where {}
+    when {}
+  }
+}`
+
+func Test_DataSource_PolicyDocumentV2_LintWarnings(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: lintedPolicyDocumentV2Config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document.unit_test", "lint_warnings.#", "1"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document.unit_test", "lint_warnings.0", "ssh exec is allowed without a commands list, any command can be executed"),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_PolicyDocumentV2_LintWarningsAsErrors(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: testProviderFactoriesWithHelper(t, &border0.ProviderHelper{
+			Requester:                  new(mocks.APIClientRequester),
+			Delayer:                    &border0.NoopDelayer{},
+			PolicyLintWarningsAsErrors: true,
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config:      lintedPolicyDocumentV2Config,
+				ExpectError: regexp.MustCompile(`Policy lint warning`),
+			},
+		},
+	})
+}
+
+func Test_DataSource_PolicyDocumentV2_ResolveWhoReferences(t *testing.T) {
+	groups := border0client.Groups{List: []border0client.Group{
+		{ID: "unit-test-group-id-1", DisplayName: "engineering"},
+		{ID: "unit-test-group-id-2", DisplayName: "sales"},
+	}}
+	users := border0client.Users{List: []border0client.User{
+		{ID: "unit-test-user-id-1", Email: "johndoe@example.com"},
+		{ID: "unit-test-user-id-2", Email: "janedoe@example.com"},
+	}}
+
+	clientMock := mocks.APIClientRequester{}
+	var calls []*mock.Call
+	// refresh for startup, refresh for apply, apply, post-apply and refresh for cleanup
+	for range 5 {
+		calls = append(calls,
+			clientMock.EXPECT().Groups(matchContext).Return(&groups, nil).Call,
+			clientMock.EXPECT().Users(matchContext).Return(&users, nil).Call,
+		)
+	}
+	mockCallsInOrder(calls...)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "border0_policy_v2_document" "unit_test" {
+						permissions {
+							http {
+								allowed = true
+							}
+						}
+						condition {
+							who {
+								email      = [ "johndoe@example.com" ]
+								group_name = [ "engineering" ]
+								user_id    = [ "unit-test-user-id-1", "unit-test-user-id-2" ]
+							}
+							where {}
+							when {}
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					testMatchResourceAttrJSON("data.border0_policy_v2_document.unit_test", "json", `{
+						"permissions": { "http": {} },
+						"condition": {
+							"who": {
+								"email": [ "johndoe@example.com", "janedoe@example.com" ],
+								"group": [ "unit-test-group-id-1" ],
+								"service_account": []
+							},
+							"where": { "allowed_ip": null, "country": null, "country_not": null },
+							"when": { "after": "", "before": "", "time_of_day_after": "", "time_of_day_before": "" }
+						}
+					}`),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document.unit_test", "resolved_group_ids.%", "1"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document.unit_test", "resolved_group_ids.engineering", "unit-test-group-id-1"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document.unit_test", "resolved_user_emails.%", "2"),
+					resource.TestCheckResourceAttr("data.border0_policy_v2_document.unit_test", "resolved_user_emails.unit-test-user-id-2", "janedoe@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_PolicyDocumentV2_UnresolvableWhoReferences(t *testing.T) {
+	clientMock := mocks.APIClientRequester{}
+	clientMock.EXPECT().Groups(matchContext).Return(&border0client.Groups{List: []border0client.Group{
+		{ID: "unit-test-group-id-1", DisplayName: "engineering"},
+		{ID: "unit-test-group-id-2", DisplayName: "engineering"},
+	}}, nil)
+	clientMock.EXPECT().Users(matchContext).Return(&border0client.Users{}, nil)
+
+	config := `
+		data "border0_policy_v2_document" "unit_test" {
+			condition {
+				who {
+					%s = [ %q ]
+				}
+				where {}
+				when {}
+			}
+		}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(config, "group_name", "sales"),
+				ExpectError: regexp.MustCompile(`Group "sales" in condition.who.group_name not found`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "group_name", "engineering"),
+				ExpectError: regexp.MustCompile(`Group name "engineering" in condition.who.group_name matches more than one group`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "user_id", "unit-test-user-id-1"),
+				ExpectError: regexp.MustCompile(`User "unit-test-user-id-1" in condition.who.user_id not found`),
+			},
+		},
+	})
+}
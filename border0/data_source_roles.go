@@ -0,0 +1,142 @@
+package border0
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// roleLister is implemented by API clients that can list the roles of the organization, keyed by
+// name with their descriptions. The border0 go sdk doesn't list roles yet, clients without it are
+// validated against the built-in roles.
+type roleLister interface {
+	Roles(ctx context.Context) (map[string]string, error)
+}
+
+// builtinRoles are the roles that can be assigned to users and service accounts, keyed by name,
+// with a short summary of each role as their description.
+var builtinRoles = map[string]string{
+	"admin":     "Administrator of the organization.",
+	"member":    "Member of the organization.",
+	"read only": "Read only access to the organization.",
+	"client":    "Client access to the sockets allowed by policies.",
+}
+
+// assignableRole is a role that can be assigned to users and service accounts.
+type assignableRole struct {
+	name        string
+	description string
+}
+
+func dataSourceRoles() *schema.Resource {
+	return &schema.Resource{
+		Description: "`border0_roles` data source can be used to list the roles that can be assigned to users and service accounts. The roles are fetched from the API when it supports listing them, and are the built-in roles otherwise.",
+		ReadContext: dataSourceRolesRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the roles, ordered by name.",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The roles, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the role, as used by the `role` of users and service accounts.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the role.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	roles, err := assignableRoles(ctx, m)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch roles list")
+	}
+
+	names := make([]string, 0, len(roles))
+	flattenedRoles := make([]map[string]any, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.name)
+		flattenedRoles = append(flattenedRoles, map[string]any{
+			"name":        role.name,
+			"description": role.description,
+		})
+	}
+
+	d.SetId(strconv.Itoa(stringHashcode(strings.Join(names, ","))))
+	return schemautil.SetValues(d, map[string]any{
+		"names": names,
+		"roles": flattenedRoles,
+	})
+}
+
+// assignableRoles returns the roles of the organization, ordered by name. They are fetched from the
+// API when the client supports it, and are the built-in roles otherwise.
+func assignableRoles(ctx context.Context, m any) ([]assignableRole, error) {
+	if helper, ok := m.(*ProviderHelper); ok {
+		m = helper.Requester
+	}
+
+	descriptions := builtinRoles
+	if lister, ok := m.(roleLister); ok {
+		fetched, err := lister.Roles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		descriptions = fetched
+	}
+
+	roles := make([]assignableRole, 0, len(descriptions))
+	for name, description := range descriptions {
+		roles = append(roles, assignableRole{name: name, description: description})
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].name < roles[j].name })
+	return roles, nil
+}
+
+// customizeDiffRole validates the role of a user or service account against the roles of the
+// organization, so a typo fails the plan instead of the apply.
+func customizeDiffRole(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if !d.HasChange("role") || !d.NewValueKnown("role") {
+		return nil
+	}
+	roles, err := assignableRoles(ctx, m)
+	if err != nil {
+		return fmt.Errorf("failed to fetch roles list: %w", err)
+	}
+	return validateRole(roles, d.Get("role").(string))
+}
+
+// validateRole returns an error when the role is not one of the given roles.
+func validateRole(roles []assignableRole, role string) error {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r.name == role {
+			return nil
+		}
+		names = append(names, strconv.Quote(r.name))
+	}
+	return fmt.Errorf("role %q is not valid, expected one of %s", role, strings.Join(names, ", "))
+}
@@ -0,0 +1,119 @@
+package border0_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// roleListerMock is an API client that can list the roles of the organization.
+type roleListerMock struct {
+	*mocks.APIClientRequester
+	roles map[string]string
+}
+
+func (r *roleListerMock) Roles(ctx context.Context) (map[string]string, error) {
+	return r.roles, nil
+}
+
+func Test_DataSource_Roles(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &mocks.APIClientRequester{}),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "border0_roles" "unit_test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.#", "4"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.0", "admin"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.1", "client"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.2", "member"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.3", "read only"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.#", "4"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.0.name", "admin"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.0.description", "Administrator of the organization."),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.3.name", "read only"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.3.description", "Read only access to the organization."),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_Roles_FromAPI(t *testing.T) {
+	clientMock := &roleListerMock{
+		APIClientRequester: &mocks.APIClientRequester{},
+		roles: map[string]string{
+			"member":  "Members of the organization.",
+			"auditor": "Can view audit logs.",
+		},
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "border0_roles" "unit_test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.0", "auditor"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "names.1", "member"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.0.name", "auditor"),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.0.description", "Can view audit logs."),
+					resource.TestCheckResourceAttr("data.border0_roles.unit_test", "roles.1.description", "Members of the organization."),
+				),
+			},
+			{
+				// built-in roles that the organization doesn't have are rejected too
+				Config: `
+				resource "border0_user" "unit_test" {
+					display_name = "Unit Test"
+					email        = "user@unit-test.com"
+					role         = "admin"
+				}`,
+				ExpectError: regexp.MustCompile(`role "admin" is not valid, expected one of "auditor", "member"`),
+			},
+		},
+	})
+}
+
+func Test_Resource_InvalidRole(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &mocks.APIClientRequester{}),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "border0_user" "unit_test" {
+					display_name = "Unit Test"
+					email        = "user@unit-test.com"
+					role         = "amdin"
+				}`,
+				ExpectError: regexp.MustCompile(`role "amdin" is not valid, expected one of "admin", "client", "member", "read only"`),
+			},
+			{
+				Config: `
+				resource "border0_service_account" "unit_test" {
+					name = "unit-test"
+					role = "readonly"
+				}`,
+				ExpectError: regexp.MustCompile(`role "readonly" is not valid`),
+			},
+			{
+				Config: `
+				resource "border0_user_roster" "unit_test" {
+					user {
+						email        = "user@unit-test.com"
+						display_name = "Unit Test"
+						role         = "owner"
+					}
+				}`,
+				ExpectError: regexp.MustCompile(`invalid user user@unit-test.com: role "owner" is not valid`),
+			},
+		},
+	})
+}
@@ -0,0 +1,111 @@
+package border0
+
+import (
+	"context"
+	"strings"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUser() *schema.Resource {
+	userSchema := userDataSourceAttributes()
+	userSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "email"},
+		Description:  "The ID of the user to look up. Exactly one of `id` or `email` must be set.",
+	}
+	userSchema["email"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "email"},
+		Description:  "The email address of the user to look up, matched case-insensitively. Exactly one of `id` or `email` must be set.",
+	}
+
+	return &schema.Resource{
+		Description: "`border0_user` data source can be used to look up an existing user, e.g. one that is not managed by terraform, by its ID or email address.",
+		ReadContext: dataSourceUserRead,
+		Schema:      userSchema,
+	}
+}
+
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	var user *border0client.User
+	if id, ok := d.GetOk("id"); ok {
+		found, err := client.User(ctx, id.(string))
+		if err != nil {
+			return diagnostics.Error(err, "Failed to fetch user")
+		}
+		user = found
+	} else {
+		email := d.Get("email").(string)
+
+		// client.Users drains the go sdk's UsersPaginator with its default page size, so every page of
+		// users is searched, there's no lookup by email to use instead.
+		users, err := client.Users(ctx)
+		if err != nil {
+			return diagnostics.Error(err, "Failed to fetch users list")
+		}
+		for i := range users.List {
+			if strings.EqualFold(users.List[i].Email, email) {
+				user = &users.List[i]
+				break
+			}
+		}
+		if user == nil {
+			return diag.Errorf("User with email %q not found", email)
+		}
+	}
+
+	d.SetId(user.ID)
+	return schemautil.SetValues(d, flattenUser(user))
+}
+
+// userDataSourceAttributes returns the computed attributes shared by the
+// border0_user data source and the elements of the border0_users data source.
+func userDataSourceAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the user.",
+		},
+		"email": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The email address of the user.",
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The display name of the user.",
+		},
+		"role": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The role of the user, e.g. `admin`, `member`, `read only` or `client`.",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the user, e.g. `active` or `invited` for users who haven't logged in yet.",
+		},
+	}
+}
+
+func flattenUser(user *border0client.User) map[string]any {
+	return map[string]any{
+		"email":        user.Email,
+		"display_name": user.DisplayName,
+		"role":         user.Role,
+		"status":       user.Status,
+	}
+}
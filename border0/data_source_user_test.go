@@ -0,0 +1,115 @@
+package border0_test
+
+import (
+	"regexp"
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+var mockOrgUsers = border0client.Users{
+	List: []border0client.User{
+		{ID: "unit-test-user-id-1", Email: "johndoe@example.com", DisplayName: "John Doe", Role: "admin", Status: "active"},
+		{ID: "unit-test-user-id-2", Email: "janedoe@example.com", DisplayName: "Jane Doe", Role: "member", Status: "invited"},
+		{ID: "unit-test-user-id-3", Email: "contractor@partner.com", DisplayName: "Contractor", Role: "member", Status: "active"},
+	},
+}
+
+func Test_DataSource_User_ByEmail(t *testing.T) {
+	config := `
+		data "border0_user" "unit_test" {
+			email = "JohnDoe@example.com"
+		}`
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// refresh for startup
+		clientMock.EXPECT().Users(matchContext).Return(&mockOrgUsers, nil).Call,
+
+		// refresh for apply, apply, and post-apply
+		clientMock.EXPECT().Users(matchContext).Return(&mockOrgUsers, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(&mockOrgUsers, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(&mockOrgUsers, nil).Call,
+
+		// refresh for cleanup
+		clientMock.EXPECT().Users(matchContext).Return(&mockOrgUsers, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "id", "unit-test-user-id-1"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "email", "johndoe@example.com"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "display_name", "John Doe"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "role", "admin"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_User_ByID(t *testing.T) {
+	config := `
+		data "border0_user" "unit_test" {
+			id = "unit-test-user-id-2"
+		}`
+
+	user := mockOrgUsers.List[1]
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// refresh for startup
+		clientMock.EXPECT().User(matchContext, "unit-test-user-id-2").Return(&user, nil).Call,
+
+		// refresh for apply, apply, and post-apply
+		clientMock.EXPECT().User(matchContext, "unit-test-user-id-2").Return(&user, nil).Call,
+		clientMock.EXPECT().User(matchContext, "unit-test-user-id-2").Return(&user, nil).Call,
+		clientMock.EXPECT().User(matchContext, "unit-test-user-id-2").Return(&user, nil).Call,
+
+		// refresh for cleanup
+		clientMock.EXPECT().User(matchContext, "unit-test-user-id-2").Return(&user, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "id", "unit-test-user-id-2"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "email", "janedoe@example.com"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "display_name", "Jane Doe"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "role", "member"),
+					resource.TestCheckResourceAttr("data.border0_user.unit_test", "status", "invited"),
+				),
+			},
+		},
+	})
+}
+
+func Test_DataSource_User_NotFound(t *testing.T) {
+	clientMock := mocks.APIClientRequester{}
+	clientMock.EXPECT().Users(matchContext).Return(&mockOrgUsers, nil)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "border0_user" "unit_test" {
+						email = "nobody@example.com"
+					}`,
+				ExpectError: regexp.MustCompile(`User with email "nobody@example.com" not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package border0
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Description: "`border0_users` data source can be used to list the users in the organization, optionally filtered by role and email domain.",
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users with this role, e.g. `admin`, `member`, `read only` or `client`.",
+			},
+			"email_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users whose email address is in this domain, e.g. `example.com`. Matched case-insensitively.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching users, ordered by email address.",
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching users, ordered by email address.",
+				Elem: &schema.Resource{
+					Schema: userDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	// client.Users drains the go sdk's UsersPaginator with its default page size, so it lists every
+	// page of users, the same as iterating over the paginator here would. The filters are applied
+	// afterwards because the users endpoint can't filter by role or email domain.
+	users, err := client.Users(ctx)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch users list")
+	}
+
+	role := d.Get("role").(string)
+	emailDomain := strings.TrimPrefix(d.Get("email_domain").(string), "@")
+
+	var matched []border0client.User
+	for _, user := range users.List {
+		if role != "" && user.Role != role {
+			continue
+		}
+		if emailDomain != "" {
+			_, domain, _ := strings.Cut(user.Email, "@")
+			if !strings.EqualFold(domain, emailDomain) {
+				continue
+			}
+		}
+		matched = append(matched, user)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Email < matched[j].Email })
+
+	ids := make([]string, 0, len(matched))
+	flattenedUsers := make([]map[string]any, 0, len(matched))
+	for i := range matched {
+		flattened := flattenUser(&matched[i])
+		flattened["id"] = matched[i].ID
+		ids = append(ids, matched[i].ID)
+		flattenedUsers = append(flattenedUsers, flattened)
+	}
+
+	d.SetId(strconv.Itoa(stringHashcode(strings.Join(ids, ","))))
+	return schemautil.SetValues(d, map[string]any{
+		"ids":   ids,
+		"users": flattenedUsers,
+	})
+}
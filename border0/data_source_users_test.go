@@ -0,0 +1,46 @@
+package border0_test
+
+import (
+	"testing"
+
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_DataSource_Users(t *testing.T) {
+	config := `
+		data "border0_users" "unit_test" {
+			role         = "member"
+			email_domain = "example.com"
+		}
+		data "border0_users" "all" {}`
+
+	clientMock := mocks.APIClientRequester{}
+	// both data sources list the users in every refresh
+	clientMock.EXPECT().Users(matchContext).Return(&mockOrgUsers, nil).Times(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "ids.0", "unit-test-user-id-2"),
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "users.0.id", "unit-test-user-id-2"),
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "users.0.email", "janedoe@example.com"),
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "users.0.display_name", "Jane Doe"),
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "users.0.role", "member"),
+					resource.TestCheckResourceAttr("data.border0_users.unit_test", "users.0.status", "invited"),
+
+					resource.TestCheckResourceAttr("data.border0_users.all", "ids.#", "3"),
+					resource.TestCheckResourceAttr("data.border0_users.all", "ids.0", "unit-test-user-id-3"),
+					resource.TestCheckResourceAttr("data.border0_users.all", "ids.1", "unit-test-user-id-2"),
+					resource.TestCheckResourceAttr("data.border0_users.all", "ids.2", "unit-test-user-id-1"),
+				),
+			},
+		},
+	})
+}
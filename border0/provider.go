@@ -53,22 +53,42 @@ func Provider(options ...ProviderOption) *schema.Provider {
 				Optional:    true,
 				Description: "The timeout for each HTTP request. Can also be set with the `BORDER0_HTTP_CLIENT_TIMEOUT` environment variable. Defaults to `30s`.",
 			},
+			"policy_lint_warnings_as_errors": {
+				Type:        schema.TypeBool,
+				DefaultFunc: schema.EnvDefaultFunc("BORDER0_POLICY_LINT_WARNINGS_AS_ERRORS", false),
+				Optional:    true,
+				Description: "Whether policy lint warnings, e.g. ssh exec allowed without a commands list, fail the plan instead of being reported in `lint_warnings`. Can also be set with the `BORDER0_POLICY_LINT_WARNINGS_AS_ERRORS` environment variable. Defaults to `false`.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"border0_socket":                resourceSocket(semaphore),
-			"border0_policy":                resourcePolicy(semaphore),
-			"border0_policy_attachment":     resourcePolicyAttachment(),
-			"border0_connector":             resourceConnector(),
-			"border0_connector_token":       resourceConnectorToken(),
-			"border0_user":                  resourceUser(),
-			"border0_group":                 resourceGroup(),
-			"border0_service_account":       resourceServiceAccount(),
-			"border0_service_account_token": resourceServiceAccountToken(),
+			"border0_socket":                    resourceSocket(semaphore),
+			"border0_policy":                    resourcePolicy(semaphore),
+			"border0_policy_attachment":         resourcePolicyAttachment(),
+			"border0_policy_socket_attachments": resourcePolicySocketAttachments(),
+			"border0_connector":                 resourceConnector(),
+			"border0_connector_token":           resourceConnectorToken(),
+			"border0_user":                      resourceUser(),
+			"border0_user_roster":               resourceUserRoster(),
+			"border0_group":                     resourceGroup(),
+			"border0_group_membership":          resourceGroupMembership(),
+			"border0_service_account":           resourceServiceAccount(),
+			"border0_service_account_token":     resourceServiceAccountToken(),
+			"border0_socket_ssh_certificate":    resourceSocketSSHCertificate(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"border0_policy_v2_document": dataSourcePolicyV2Document(),
-			"border0_user_emails_to_ids": dataSourceUserEmailsToIDs(),
-			"border0_group_names_to_ids": dataSourceGroupNamesToIDs(),
+			"border0_policy_v2_document":       dataSourcePolicyV2Document(),
+			"border0_policy_v2_document_parse": dataSourcePolicyV2DocumentParse(),
+			"border0_policy_evaluation":        dataSourcePolicyEvaluation(),
+			"border0_policy_v1_to_v2":          dataSourcePolicyV1ToV2(),
+			"border0_user_emails_to_ids":       dataSourceUserEmailsToIDs(),
+			"border0_user":                     dataSourceUser(),
+			"border0_users":                    dataSourceUsers(),
+			"border0_roles":                    dataSourceRoles(),
+			"border0_group_names_to_ids":       dataSourceGroupNamesToIDs(),
+			"border0_group":                    dataSourceGroup(),
+			"border0_groups":                   dataSourceGroups(),
+			"border0_policy":                   dataSourcePolicy(),
+			"border0_policies":                 dataSourcePolicies(),
 
 			// deprecated
 			"border0_policy_document": dataSourcePolicyDocument(),
@@ -126,14 +146,24 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 	}
 
 	return &ProviderHelper{
-		Requester: client,
-		Delayer:   &delayer{delay},
+		Requester:                  client,
+		Delayer:                    &delayer{delay},
+		PolicyLintWarningsAsErrors: d.Get("policy_lint_warnings_as_errors").(bool),
 	}, nil
 }
 
 type ProviderHelper struct {
 	border0client.Requester
 	Delayer
+
+	// PolicyLintWarningsAsErrors escalates policy lint warnings to errors.
+	PolicyLintWarningsAsErrors bool
+}
+
+// policyLintWarningsAsErrors returns whether the provider is configured to escalate policy lint warnings to errors.
+func policyLintWarningsAsErrors(m any) bool {
+	helper, ok := m.(*ProviderHelper)
+	return ok && helper.PolicyLintWarningsAsErrors
 }
 
 type Delayer interface {
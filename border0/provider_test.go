@@ -42,14 +42,20 @@ func mockCallsInOrder(calls ...*mock.Call) {
 func testProviderFactories(t *testing.T, api border0client.Requester) map[string]func() (*schema.Provider, error) {
 	t.Helper()
 
+	return testProviderFactoriesWithHelper(t, &border0.ProviderHelper{
+		Requester: api,
+		Delayer:   &border0.NoopDelayer{},
+	})
+}
+
+func testProviderFactoriesWithHelper(t *testing.T, helper *border0.ProviderHelper) map[string]func() (*schema.Provider, error) {
+	t.Helper()
+
 	return map[string]func() (*schema.Provider, error){
 		"border0": func() (*schema.Provider, error) {
 			return border0.Provider(func(p *schema.Provider) {
 				p.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
-					return &border0.ProviderHelper{
-						Requester: api,
-						Delayer:   &border0.NoopDelayer{},
-					}, nil
+					return helper, nil
 				}
 				p.Schema = nil // no need to include any of the global configuration
 			}), nil
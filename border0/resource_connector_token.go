@@ -3,6 +3,8 @@ package border0
 import (
 	"context"
 	"log"
+	"maps"
+	"time"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
@@ -11,14 +13,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// connectorTokenIDCodec encodes the ids of connector tokens.
+var connectorTokenIDCodec = schemautil.NewIDCodec("connector_id", "token_id")
+
 func resourceConnectorToken() *schema.Resource {
-	return &schema.Resource{
-		Description:   "The connector token resource allows you to create and delete a token for a Border0 connector.",
+	tokenResource := &schema.Resource{
+		Description:   "The connector token resource allows you to create and delete a token for a Border0 connector. Tokens can be rotated automatically with `rotation_period` and `rotate_before_expiry`.",
+		SchemaVersion: 1,
 		ReadContext:   resourceConnectorTokenRead,
 		CreateContext: resourceConnectorTokenCreate,
+		UpdateContext: resourceConnectorTokenUpdate,
 		DeleteContext: resourceConnectorTokenDelete,
+		CustomizeDiff: customizeDiffTokenRotation,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: connectorTokenIDCodec.ImportStateContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"connector_id": {
@@ -47,13 +55,18 @@ func resourceConnectorToken() *schema.Resource {
 			},
 		},
 	}
+	maps.Copy(tokenResource.Schema, tokenRotationAttributes())
+	tokenResource.StateUpgraders = []schema.StateUpgrader{
+		connectorTokenIDCodec.LegacyIDStateUpgrader(0, tokenResource),
+	}
+	return tokenResource
 }
 
 func resourceConnectorTokenRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := m.(border0client.Requester)
 
 	var connectorID, connectorTokenID string
-	diags := schemautil.LoadMultipartID(d, &connectorID, &connectorTokenID)
+	diags := connectorTokenIDCodec.Load(d, &connectorID, &connectorTokenID)
 	if diags.HasError() {
 		return diags
 	}
@@ -72,10 +85,15 @@ func resourceConnectorTokenRead(ctx context.Context, d *schema.ResourceData, m a
 		return diagnostics.Error(err, "Failed to fetch connector tokens")
 	}
 
-	return schemautil.SetValues(d, map[string]any{
+	values := map[string]any{
 		"name":       connectorToken.Name,
 		"expires_at": connectorToken.ExpiresAt.String(),
-	})
+	}
+	// the creation time recorded by the create is kept when the api doesn't return it
+	if createdAt := tokenCreatedAt(connectorToken.CreatedAt); createdAt != "" {
+		values["created_at"] = createdAt
+	}
+	return schemautil.SetValues(d, values)
 }
 
 func resourceConnectorTokenCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -100,11 +118,13 @@ func resourceConnectorTokenCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diagnostics.Error(err, "Failed to create connector token")
 	}
-	schemautil.SetMultipartID(d, connectorID, created.ID)
+	connectorTokenIDCodec.Set(d, connectorID, created.ID)
 
 	if diags := schemautil.SetValues(d, map[string]any{
-		"connector_id": connectorID,
-		"token":        created.Token,
+		"connector_id":       connectorID,
+		"token":              created.Token,
+		"created_at":         time.Now().UTC().Format(time.RFC3339),
+		"ready_for_rotation": false,
 	}); diags.HasError() {
 		return diags
 	}
@@ -113,11 +133,16 @@ func resourceConnectorTokenCreate(ctx context.Context, d *schema.ResourceData, m
 	return resourceConnectorTokenRead(ctx, d, m)
 }
 
+func resourceConnectorTokenUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	// only the rotation settings can change in place, which just move the rotation window
+	return resourceConnectorTokenRead(ctx, d, m)
+}
+
 func resourceConnectorTokenDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := m.(border0client.Requester)
 
 	var connectorID, connectorTokenID string
-	diags := schemautil.LoadMultipartID(d, &connectorID, &connectorTokenID)
+	diags := connectorTokenIDCodec.Load(d, &connectorID, &connectorTokenID)
 	if diags.HasError() {
 		return diags
 	}
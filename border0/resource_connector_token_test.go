@@ -1,7 +1,9 @@
 package border0_test
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/terraform-provider-border0/mocks"
@@ -69,6 +71,8 @@ func Test_Resource_Border0ConnectorToken_NeverExpires(t *testing.T) {
 					resource.TestCheckResourceAttr("border0_connector_token.unit_test_never_expires", "name", "unit-test-connector-token-never-expires"),
 					resource.TestCheckResourceAttr("border0_connector_token.unit_test_never_expires", "connector_id", "unit-test-connector-id"),
 					resource.TestCheckResourceAttrSet("border0_connector_token.unit_test_never_expires", "id"),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test_never_expires", "created_at", "2020-01-02T15:04:05Z"),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test_never_expires", "ready_for_rotation", "false"),
 				),
 			},
 			{
@@ -130,6 +134,8 @@ func Test_Resource_Border0ConnectorToken_Expires(t *testing.T) {
 					resource.TestCheckResourceAttr("border0_connector_token.unit_test_expires", "name", "unit-test-connector-token-never-expires"),
 					resource.TestCheckResourceAttr("border0_connector_token.unit_test_expires", "connector_id", "unit-test-connector-id"),
 					resource.TestCheckResourceAttrSet("border0_connector_token.unit_test_expires", "id"),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test_expires", "created_at", "2020-01-02T15:04:05Z"),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test_expires", "ready_for_rotation", "false"),
 				),
 			},
 			{
@@ -140,3 +146,144 @@ func Test_Resource_Border0ConnectorToken_Expires(t *testing.T) {
 		},
 	})
 }
+
+func Test_Resource_Border0ConnectorToken_Rotation(t *testing.T) {
+	oldCreatedAt, err := border0client.FlexibleTimeFrom("2020-01-02T15:04:05Z")
+	require.NoError(t, err)
+
+	input := border0client.ConnectorToken{
+		ConnectorID: "unit-test-connector-id",
+		Name:        "unit-test-connector-token-rotated",
+	}
+	oldToken := border0client.ConnectorToken{
+		ConnectorID: "unit-test-connector-id",
+		Name:        "unit-test-connector-token-rotated",
+		ID:          "unit-test-connector-token-id-1",
+		Token:       "unit-test-connector-token-1",
+		CreatedAt:   oldCreatedAt,
+	}
+	newToken := border0client.ConnectorToken{
+		ConnectorID: "unit-test-connector-id",
+		Name:        "unit-test-connector-token-rotated",
+		ID:          "unit-test-connector-token-id-2",
+		Token:       "unit-test-connector-token-2",
+		CreatedAt:   border0client.FlexibleTime{Time: time.Now().UTC().Truncate(time.Second)},
+	}
+
+	config := `
+	resource "border0_connector_token" "unit_test" {
+		connector_id    = "unit-test-connector-id"
+		name            = "unit-test-connector-token-rotated"
+		rotation_period = "720h"
+		keepers = {
+			secret_version = "1"
+		}
+
+		lifecycle {
+			create_before_destroy = true
+		}
+	}`
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create), the old token is past its rotation period straight away
+		clientMock.EXPECT().CreateConnectorToken(matchContext, &input).Return(&oldToken, nil).Call,
+
+		// terraform apply (create before destroy)
+		clientMock.EXPECT().CreateConnectorToken(matchContext, &input).Return(&newToken, nil).Call,
+		clientMock.EXPECT().DeleteConnectorToken(matchContext, "unit-test-connector-id", oldToken.ID).Return(nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeleteConnectorToken(matchContext, "unit-test-connector-id", newToken.ID).Return(nil).Call,
+	)
+	clientMock.EXPECT().ConnectorToken(matchContext, "unit-test-connector-id", oldToken.ID).Return(&oldToken, nil)
+	clientMock.EXPECT().ConnectorToken(matchContext, "unit-test-connector-id", newToken.ID).Return(&newToken, nil)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test", "id", "unit-test-connector-id:"+oldToken.ID),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test", "created_at", "2020-01-02T15:04:05Z"),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test", "ready_for_rotation", "false"),
+				),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test", "id", "unit-test-connector-id:"+newToken.ID),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test", "token", newToken.Token),
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test", "ready_for_rotation", "false"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0ConnectorToken_RotateBeforeExpiryUnchanged(t *testing.T) {
+	createdAt, err := border0client.FlexibleTimeFrom("2020-01-02T15:04:05Z")
+	require.NoError(t, err)
+
+	expiresAt, err := border0client.FlexibleTimeFrom("2023-12-31T23:59:59Z")
+	require.NoError(t, err)
+
+	input := border0client.ConnectorToken{
+		ConnectorID: "unit-test-connector-id",
+		Name:        "unit-test-connector-token-never-expires",
+		ExpiresAt:   expiresAt,
+	}
+	output := border0client.ConnectorToken{
+		ConnectorID: "unit-test-connector-id",
+		Name:        "unit-test-connector-token-never-expires",
+		ExpiresAt:   expiresAt,
+		ID:          "unit-test-connector-token-id",
+		Token:       "unit-test-connector-token",
+		CreatedAt:   createdAt,
+	}
+
+	// the same expires_at as connmectorTokenExpiresConfig, which is already past
+	rotateBeforeExpiryConfig := `
+	resource "border0_connector_token" "unit_test_expires" {
+		connector_id         = "unit-test-connector-id"
+		name                 = "unit-test-connector-token-never-expires"
+		expires_at           = "2023-12-31T23:59:59Z"
+		rotate_before_expiry = "72h"
+	}`
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create)
+		clientMock.EXPECT().CreateConnectorToken(matchContext, &input).Return(&output, nil).Call,
+
+		// terraform destroy (delete), the token is never replaced
+		clientMock.EXPECT().DeleteConnectorToken(matchContext, "unit-test-connector-id", "unit-test-connector-token-id").Return(nil).Call,
+	)
+	clientMock.EXPECT().ConnectorToken(matchContext, "unit-test-connector-id", "unit-test-connector-token-id").Return(&output, nil)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: connmectorTokenExpiresConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_connector_token.unit_test_expires", "id", "unit-test-connector-id:unit-test-connector-token-id"),
+				),
+			},
+			{
+				// a replacement would keep the same expires_at, so every later plan would replace it again
+				Config:      rotateBeforeExpiryConfig,
+				ExpectError: regexp.MustCompile(`expires_at "2023-12-31T23:59:59Z" is within rotate_before_expiry \(72h\)`),
+			},
+			{
+				// and so does the next plan, instead of a replacement that is due straight away
+				Config:      rotateBeforeExpiryConfig,
+				ExpectError: regexp.MustCompile(`a new token would expire at the same time and be replaced on every plan`),
+			},
+		},
+	})
+}
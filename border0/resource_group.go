@@ -2,13 +2,17 @@ package border0
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/border0-go/lib/types/slice"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
 	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
 	"github.com/borderzero/terraform-provider-border0/internal/schemautil/schemaconvert"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,7 +26,7 @@ func resourceGroup() *schema.Resource {
 		UpdateContext: resourceGroupUpdate,
 		DeleteContext: resourceGroupDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceGroupImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"display_name": {
@@ -31,9 +35,19 @@ func resourceGroup() *schema.Resource {
 				Description: "The display name for the user. A friendly name to help distinguish it among other users.",
 			},
 			"members": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Set of user ids (members of the group)",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"member_emails"},
+				Description:   "Set of user ids (members of the group)",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"member_emails": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"members"},
+				Description:   "Set of user emails (members of the group), an alternative to `members`. Emails are resolved to user ids against the users in the organization when the group is created or updated, and matched case-insensitively.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -56,10 +70,19 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m any) diag.
 		return diagnostics.Error(err, "Failed to fetch group")
 	}
 
-	return schemautil.SetValues(d, map[string]any{
-		"display_name": group.DisplayName,
-		"members":      slice.Transform(group.Members, func(u border0client.User) string { return u.ID }),
-	})
+	values := map[string]any{
+		"display_name":  group.DisplayName,
+		"members":       []any{},
+		"member_emails": []any{},
+	}
+	// members are reported back in the same form as they are configured
+	if memberEmails, ok := d.GetOk("member_emails"); ok {
+		values["member_emails"] = groupMemberEmails(group, schemaconvert.SetToSlice[string](memberEmails.(*schema.Set)))
+	} else {
+		values["members"] = slice.Transform(group.Members, func(u border0client.User) string { return u.ID })
+	}
+
+	return schemautil.SetValues(d, values)
 }
 
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -70,15 +93,9 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m any) dia
 		DisplayName: d.Get("display_name").(string),
 	}
 
-	members := []string{}
-	if v := d.Get("members"); v != nil {
-		members = schemaconvert.SetToSlice[string](v.(*schema.Set))
-	}
-	// client side validation for member IDs
-	for _, member := range members {
-		if _, err := uuid.ParseUUID(member); err != nil {
-			return diagnostics.Error(err, "member id %s not a valid user uuid", member)
-		}
+	members, diags := groupMembersFromConfig(ctx, client, d)
+	if diags.HasError() {
+		return diags
 	}
 
 	created, err := client.CreateGroup(ctx, group)
@@ -112,15 +129,10 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m any) dia
 		ID: d.Id(),
 	}
 
-	if d.HasChanges("members") {
-		members := []string{}
-		if v := d.Get("members"); v != nil {
-			members = schemaconvert.SetToSlice[string](v.(*schema.Set))
-		}
-		for _, member := range members {
-			if _, err := uuid.ParseUUID(member); err != nil {
-				return diagnostics.Error(err, "member id %s not a valid user uuid", member)
-			}
+	if d.HasChanges("members", "member_emails") {
+		members, diags := groupMembersFromConfig(ctx, client, d)
+		if diags.HasError() {
+			return diags
 		}
 		if _, err := client.UpdateGroupMemberships(ctx, group, members); err != nil {
 			return diagnostics.Error(err, "Failed to update group memberships")
@@ -146,3 +158,111 @@ func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m any) dia
 	d.SetId("")
 	return nil
 }
+
+func resourceGroupImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	client := m.(border0client.Requester)
+
+	// groups can be imported by display name as well as by id
+	displayName := d.Id()
+	if _, err := uuid.ParseUUID(displayName); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	// NOTE: internally uses the border0 go sdk's groups paginator
+	// to retrieve all pages of groups in the organization, using
+	// the default page size defined there.
+	groups, err := client.Groups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch groups list: %w", err)
+	}
+
+	var ids []string
+	for _, group := range groups.List {
+		if group.DisplayName == displayName {
+			ids = append(ids, group.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		// not a display name either, so it is imported as an id
+	case 1:
+		d.SetId(ids[0])
+	default:
+		sort.Strings(ids)
+		return nil, fmt.Errorf("group display name %q matches more than one group (%s), import by id instead", displayName, strings.Join(ids, ", "))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// groupMembersFromConfig returns the user ids of the configured group members,
+// resolving member_emails against the users in the organization when it is set.
+func groupMembersFromConfig(ctx context.Context, client border0client.Requester, d *schema.ResourceData) ([]string, diag.Diagnostics) {
+	if v, ok := d.GetOk("member_emails"); ok {
+		return resolveMemberEmails(ctx, client, schemaconvert.SetToSlice[string](v.(*schema.Set)))
+	}
+
+	members := []string{}
+	if v := d.Get("members"); v != nil {
+		members = schemaconvert.SetToSlice[string](v.(*schema.Set))
+	}
+	// client side validation for member IDs
+	for _, member := range members {
+		if _, err := uuid.ParseUUID(member); err != nil {
+			return nil, diagnostics.Error(err, "member id %s not a valid user uuid", member)
+		}
+	}
+	return members, nil
+}
+
+// resolveMemberEmails returns the user ids for the given emails, with an error for each email
+// that doesn't belong to a user in the organization.
+func resolveMemberEmails(ctx context.Context, client border0client.Requester, emails []string) ([]string, diag.Diagnostics) {
+	// NOTE: internally uses the border0 go sdk's users paginator
+	// to retrieve all pages of users in the organization, using
+	// the default page size defined there.
+	users, err := client.Users(ctx)
+	if err != nil {
+		return nil, diagnostics.Error(err, "Failed to fetch users list")
+	}
+
+	idsByEmail := map[string]string{}
+	for _, user := range users.List {
+		idsByEmail[strings.ToLower(user.Email)] = user.ID
+	}
+
+	sort.Strings(emails)
+	members := []string{}
+	var diags diag.Diagnostics
+	for _, email := range emails {
+		id, ok := idsByEmail[strings.ToLower(email)]
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("User with email %q not found", email),
+				Detail:        "Every email in member_emails must belong to a user in the organization, users can be added with the border0_user resource.",
+				AttributePath: cty.GetAttrPath("member_emails"),
+			})
+			continue
+		}
+		members = append(members, id)
+	}
+	return members, diags
+}
+
+// groupMemberEmails returns the emails of the group members, spelled the same way
+// as the configured emails when they only differ in case.
+func groupMemberEmails(group *border0client.Group, configured []string) []string {
+	emails := make([]string, 0, len(group.Members))
+	for _, member := range group.Members {
+		email := member.Email
+		for _, c := range configured {
+			if strings.EqualFold(c, email) {
+				email = c
+				break
+			}
+		}
+		emails = append(emails, email)
+	}
+	return emails
+}
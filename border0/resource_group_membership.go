@@ -0,0 +1,170 @@
+package border0
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/border0-go/lib/types/slice"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/mutexkv"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// groupMembershipLocks serializes the read-modify-write of a group's members, the API only
+// supports replacing all of them at once.
+var groupMembershipLocks mutexkv.MutexKV
+
+// groupMembershipIDCodec encodes the ids of group memberships.
+var groupMembershipIDCodec = schemautil.NewIDCodec("group_id", "user_id")
+
+func resourceGroupMembership() *schema.Resource {
+	membershipResource := &schema.Resource{
+		Description:   "Adds a single user to a group, without managing the group's other members. Use it to add members to a shared group from several configurations. It must not be used together with the `members` of a `border0_group` resource for the same group, which would remove the member again.",
+		SchemaVersion: 1,
+		ReadContext:   resourceGroupMembershipRead,
+		CreateContext: resourceGroupMembershipCreate,
+		DeleteContext: resourceGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupMembershipImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the group to add the user to.",
+			},
+			"user_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "The ID of the user to add to the group.",
+			},
+		},
+	}
+	membershipResource.StateUpgraders = []schema.StateUpgrader{
+		groupMembershipIDCodec.LegacyIDStateUpgrader(0, membershipResource),
+	}
+	return membershipResource
+}
+
+func resourceGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	var groupID, userID string
+	if diags := groupMembershipIDCodec.Load(d, &groupID, &userID); diags.HasError() {
+		return diags
+	}
+
+	group, err := client.Group(ctx, groupID)
+	if !d.IsNewResource() && border0client.NotFound(err) {
+		log.Printf("[WARN] Group (%s) not found, removing from state", groupID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch group")
+	}
+
+	// the user is no longer a member when it was removed outside of terraform,
+	// or when the user itself was deleted, in both cases the membership is gone
+	if !d.IsNewResource() && !slices.Contains(groupMemberIDs(group), userID) {
+		log.Printf("[WARN] User (%s) no longer a member of group (%s), removing from state", userID, groupID)
+		d.SetId("")
+		return nil
+	}
+
+	return schemautil.SetValues(d, map[string]any{
+		"group_id": groupID,
+		"user_id":  userID,
+	})
+}
+
+func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	helper := m.(*ProviderHelper)
+	client := helper.Requester
+
+	groupID := d.Get("group_id").(string)
+	userID := d.Get("user_id").(string)
+
+	groupMembershipLocks.Lock(groupID)
+	defer groupMembershipLocks.Unlock(groupID)
+
+	group, err := client.Group(ctx, groupID)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch group")
+	}
+
+	// adding a user that is already a member is a no-op, e.g. when it was added outside of terraform
+	if members := groupMemberIDs(group); !slices.Contains(members, userID) {
+		if _, err := client.UpdateGroupMemberships(ctx, group, append(members, userID)); err != nil {
+			return diagnostics.Error(err, "Failed to add user to group")
+		}
+	}
+	groupMembershipIDCodec.Set(d, groupID, userID)
+
+	helper.ReadAfterWriteDelay()
+	return resourceGroupMembershipRead(ctx, d, m)
+}
+
+func resourceGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	var groupID, userID string
+	if diags := groupMembershipIDCodec.Load(d, &groupID, &userID); diags.HasError() {
+		return diags
+	}
+
+	groupMembershipLocks.Lock(groupID)
+	defer groupMembershipLocks.Unlock(groupID)
+
+	// nothing left to remove if the group was already deleted
+	group, err := client.Group(ctx, groupID)
+	if border0client.NotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch group")
+	}
+
+	if members := groupMemberIDs(group); slices.Contains(members, userID) {
+		remaining := slices.DeleteFunc(members, func(id string) bool { return id == userID })
+		if _, err := client.UpdateGroupMemberships(ctx, group, remaining); err != nil {
+			return diagnostics.Error(err, "Failed to remove user from group")
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceGroupMembershipImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	client := m.(border0client.Requester)
+
+	parts, err := groupMembershipIDCodec.Decode(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	groupID, userID := parts[0], parts[1]
+
+	group, err := client.Group(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch group %s: %w", groupID, err)
+	}
+	if !slices.Contains(groupMemberIDs(group), userID) {
+		return nil, fmt.Errorf("user %s is not a member of group %s", userID, groupID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func groupMemberIDs(group *border0client.Group) []string {
+	return slice.Transform(group.Members, func(u border0client.User) string { return u.ID })
+}
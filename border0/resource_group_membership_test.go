@@ -0,0 +1,125 @@
+package border0_test
+
+import (
+	"regexp"
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+var groupMembershipConfig = `
+resource "border0_group_membership" "unit_test" {
+  group_id = "unit-test-group-id"
+  user_id  = "a8f3c94e-2f4b-4a59-9b8b-0d6d5c4b1f21"
+}
+`
+
+func Test_Resource_Border0GroupMembership(t *testing.T) {
+	groupID := "unit-test-group-id"
+	userID := "a8f3c94e-2f4b-4a59-9b8b-0d6d5c4b1f21"
+
+	existingMember := border0client.User{ID: "unit-test-user-id-1"}
+	newMember := border0client.User{ID: userID}
+
+	before := border0client.Group{ID: groupID, DisplayName: "shared", Members: []border0client.User{existingMember}}
+	after := border0client.Group{ID: groupID, DisplayName: "shared", Members: []border0client.User{existingMember, newMember}}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (read-modify-write + read + read), the other member is kept
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&before, nil).Call,
+		clientMock.EXPECT().UpdateGroupMemberships(matchContext, &before, []string{"unit-test-user-id-1", userID}).Return(&after, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&after, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&after, nil).Call,
+
+		// terraform import (import + read)
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&after, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&after, nil).Call,
+
+		// terraform import of a user that is not a member (import)
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&after, nil).Call,
+
+		// terraform destroy (read-modify-write), the other member is kept
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&after, nil).Call,
+		clientMock.EXPECT().UpdateGroupMemberships(matchContext, &after, []string{"unit-test-user-id-1"}).Return(&before, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: groupMembershipConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_group_membership.unit_test", "group_id", groupID),
+					resource.TestCheckResourceAttr("border0_group_membership.unit_test", "user_id", userID),
+					resource.TestCheckResourceAttr("border0_group_membership.unit_test", "id", groupID+":"+userID),
+				),
+			},
+			{
+				ResourceName:      "border0_group_membership.unit_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "border0_group_membership.unit_test",
+				ImportState:   true,
+				ImportStateId: "unit-test-group-id:not-a-member-id",
+				ExpectError:   regexp.MustCompile("user not-a-member-id is not a member of group unit-test-group-id"),
+			},
+			{
+				ResourceName:  "border0_group_membership.unit_test",
+				ImportState:   true,
+				ImportStateId: "unit-test-group-id",
+				ExpectError:   regexp.MustCompile("expected format is group_id:user_id"),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0GroupMembership_RemovedOutOfBand(t *testing.T) {
+	groupID := "unit-test-group-id"
+	userID := "a8f3c94e-2f4b-4a59-9b8b-0d6d5c4b1f21"
+
+	without := border0client.Group{ID: groupID, Members: []border0client.User{}}
+	with := border0client.Group{ID: groupID, Members: []border0client.User{{ID: userID}}}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (read-modify-write + read + read)
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&without, nil).Call,
+		clientMock.EXPECT().UpdateGroupMemberships(matchContext, &without, []string{userID}).Return(&with, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&with, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&with, nil).Call,
+
+		// refresh finds the user removed outside of terraform, so it is removed from state...
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&without, nil).Call,
+
+		// ...and terraform apply adds it again (read-modify-write + read + read)
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&without, nil).Call,
+		clientMock.EXPECT().UpdateGroupMemberships(matchContext, &without, []string{userID}).Return(&with, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&with, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupID).Return(&with, nil).Call,
+
+		// terraform destroy (delete), the group was deleted in the meantime
+		clientMock.EXPECT().Group(matchContext, groupID).Return(nil, border0client.Error{Code: 404, Message: "group not found"}).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: groupMembershipConfig,
+			},
+			{
+				Config: groupMembershipConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_group_membership.unit_test", "id", groupID+":"+userID),
+				),
+			},
+		},
+	})
+}
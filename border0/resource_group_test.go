@@ -2,6 +2,7 @@ package border0_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	border0client "github.com/borderzero/border0-go/client"
@@ -78,9 +79,20 @@ func Test_Resource_Border0Group(t *testing.T) {
 		clientMock.EXPECT().Group(matchContext, updateOutput.ID).Return(&updateOutput, nil).Call,
 		clientMock.EXPECT().Group(matchContext, updateOutput.ID).Return(&updateOutput, nil).Call,
 
-		// terraform import (read)
+		// terraform import (not a display name, so imported as an id + read)
+		clientMock.EXPECT().Groups(matchContext).Return(&border0client.Groups{List: []border0client.Group{updateOutput}}, nil).Call,
 		clientMock.EXPECT().Group(matchContext, updateOutput.ID).Return(&updateOutput, nil).Call,
 
+		// terraform import by display name (import + read)
+		clientMock.EXPECT().Groups(matchContext).Return(&border0client.Groups{List: []border0client.Group{updateOutput}}, nil).Call,
+		clientMock.EXPECT().Group(matchContext, updateOutput.ID).Return(&updateOutput, nil).Call,
+
+		// terraform import by a display name used by more than one group (import)
+		clientMock.EXPECT().Groups(matchContext).Return(&border0client.Groups{List: []border0client.Group{
+			{ID: "unit-test-group-id-2", DisplayName: "Duplicate"},
+			{ID: "unit-test-group-id-1", DisplayName: "Duplicate"},
+		}}, nil).Call,
+
 		// terraform destroy (delete)
 		clientMock.EXPECT().DeleteGroup(matchContext, updateOutput.ID).Return(nil).Call,
 	)
@@ -111,6 +123,98 @@ func Test_Resource_Border0Group(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "border0_group.unit_test",
+				ImportState:       true,
+				ImportStateId:     "Unit Test After",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "border0_group.unit_test",
+				ImportState:   true,
+				ImportStateId: "Duplicate",
+				ExpectError:   regexp.MustCompile(`group display name "Duplicate" matches more than one group \(unit-test-group-id-1, unit-test-group-id-2\)`),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Group_MemberEmails(t *testing.T) {
+	users := border0client.Users{
+		List: []border0client.User{
+			{ID: "unit-test-user-id-1", Email: "johndoe@example.com"},
+			{ID: "unit-test-user-id-2", Email: "janedoe@example.com"},
+		},
+	}
+
+	groupCreateInput := border0client.Group{
+		DisplayName: "Unit Test",
+	}
+	groupCreateOutput := border0client.Group{
+		ID:          "uuiduuid-uuid-uuid-uuid-uuiduuiduuid",
+		DisplayName: groupCreateInput.DisplayName,
+	}
+	groupMembershipsUpdateOutput := border0client.Group{
+		ID:          groupCreateOutput.ID,
+		DisplayName: groupCreateOutput.DisplayName,
+		Members: []border0client.User{
+			{ID: "unit-test-user-id-1", Email: "johndoe@example.com"},
+			{ID: "unit-test-user-id-2", Email: "janedoe@example.com"},
+		},
+	}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (resolve emails + create + read + read)
+		clientMock.EXPECT().Users(matchContext).Return(&users, nil).Call,
+		clientMock.EXPECT().CreateGroup(matchContext, &groupCreateInput).Return(&groupCreateOutput, nil).Call,
+		clientMock.EXPECT().UpdateGroupMemberships(matchContext, &groupCreateOutput, []string{"unit-test-user-id-1", "unit-test-user-id-2"}).Return(&groupMembershipsUpdateOutput, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupCreateOutput.ID).Return(&groupMembershipsUpdateOutput, nil).Call,
+		clientMock.EXPECT().Group(matchContext, groupCreateOutput.ID).Return(&groupMembershipsUpdateOutput, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeleteGroup(matchContext, groupCreateOutput.ID).Return(nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "border0_group" "unit_test" {
+						display_name  = "Unit Test"
+						member_emails = [ "JohnDoe@example.com", "janedoe@example.com" ]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_group.unit_test", "member_emails.#", "2"),
+					resource.TestCheckTypeSetElemAttr("border0_group.unit_test", "member_emails.*", "JohnDoe@example.com"),
+					resource.TestCheckTypeSetElemAttr("border0_group.unit_test", "member_emails.*", "janedoe@example.com"),
+					resource.TestCheckResourceAttr("border0_group.unit_test", "members.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Group_UnknownMemberEmails(t *testing.T) {
+	clientMock := mocks.APIClientRequester{}
+	clientMock.EXPECT().Users(matchContext).Return(&border0client.Users{
+		List: []border0client.User{{ID: "unit-test-user-id-1", Email: "johndoe@example.com"}},
+	}, nil)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "border0_group" "unit_test" {
+						display_name  = "Unit Test"
+						member_emails = [ "johndoe@example.com", "nobody@example.com", "ghost@example.com" ]
+					}`,
+				ExpectError: regexp.MustCompile(`(?s)User with email "ghost@example.com" not found.*User with email "nobody@example.com" not found`),
+			},
 		},
 	})
 }
@@ -3,6 +3,8 @@ package border0
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"reflect"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/border0-go/lib/types/jsoneq"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/policyutil"
 	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,12 +21,15 @@ import (
 )
 
 func resourcePolicy(semaphore *semaphore.Weighted) *schema.Resource {
+	documentSchema := dataSourcePolicyV2Document().Schema
+
 	return &schema.Resource{
 		Description:   "The policy resource allows you to create and manage a Border0 policy.",
 		ReadContext:   resourcePolicyRead,
 		CreateContext: getResourcePolicyCreate(semaphore),
 		UpdateContext: getResourcePolicyUpdate(semaphore),
 		DeleteContext: getResourcePolicyDelete(semaphore),
+		CustomizeDiff: resourcePolicyCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -37,15 +43,29 @@ func resourcePolicy(semaphore *semaphore.Weighted) *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "v2",
-				Description:  "The version of the policy. The default value is 'v2', the other valid value is 'v1'.",
+				Description:  "The version of the policy. The default value is 'v2', the other valid value is 'v1'. Changing the version of a v1 policy to 'v2' updates it in place, a v1 `policy_data` is converted to v2 the same way as the `border0_policy_v1_to_v2` data source does. The plan fails when the conversion has `lossy_mappings`, e.g. a `who.domain` condition.",
 				ValidateFunc: validation.StringInSlice([]string{"v1", "v2"}, false),
 			},
 			"policy_data": {
 				Type:                  schema.TypeString,
-				Required:              true,
+				Optional:              true,
+				Computed:              true,
+				ExactlyOneOf:          []string{"policy_data", "policy_v2"},
 				DiffSuppressFunc:      suppressEquivalentPolicyDiffs,
 				DiffSuppressOnRefresh: true,
-				Description:           "The policy data. This is a JSON string.",
+				Description:           "The policy data. This is a JSON string. The `where` and `when` conditions are validated at plan time: IPs must be addresses or CIDR blocks, countries ISO 3166-1 alpha-2 codes (two upper-case letters e.g. `NL`), `after`/`before` RFC 3339 timestamps and times of day `HH:MM` optionally followed by a time zone. Exactly one of `policy_data` and `policy_v2` must be set, when `policy_v2` is set this is computed from it.",
+			},
+			"policy_v2": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The v2 policy data as structured blocks, an alternative to `policy_data` that shows per-field diffs in plans and rejects unknown attributes. It takes the same `permissions` and `condition` blocks as the `border0_policy_v2_document` data source, and requires `version` to be `v2`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permissions": listBlockSchema(documentSchema["permissions"]),
+						"condition":   withoutDocumentOnlyWhoAttributes(listBlockSchema(documentSchema["condition"])),
+					},
+				},
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -58,10 +78,23 @@ func resourcePolicy(semaphore *semaphore.Weighted) *schema.Resource {
 				Default:     false,
 				Description: "Whether the policy should be applied to all sockets in the organization.",
 			},
-			"tag_rules": {
+			"lint_warnings": {
 				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Warnings about risky, overly broad access granted by the policy, e.g. ssh exec allowed without a commands list. They are also shown as warnings when the policy is refreshed or applied. Set the provider's `policy_lint_warnings_as_errors` to fail the plan instead.",
+			},
+			"validate_references": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "A list of tag rules to apply to the sockets that this policy is applied to.",
+				Default:     false,
+				Description: "Whether to check that every `who.email`, `who.group` and `who.service_account` of the policy exists in the organization when planning and refreshing. Principals that do not exist grant nothing, they fail the plan, and are shown as warnings when refreshing. The default value is `false`.",
+			},
+			"tag_rules": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"tag_rule"},
+				Description:   "A list of tag rules to apply to the sockets that this policy is applied to. A socket matches when it has all the tags of any one of the maps, the order of the maps doesn't matter. Conflicts with `tag_rule`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeMap,
 					Elem: &schema.Schema{
@@ -69,6 +102,35 @@ func resourcePolicy(semaphore *semaphore.Weighted) *schema.Resource {
 					},
 				},
 			},
+			"tag_rule": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"tag_rules"},
+				Description:   "Structured tag rules to apply to the sockets that this policy is applied to, an alternative to `tag_rules`. A socket matches when it satisfies all the tag rules, the order of the blocks doesn't matter. The rules are sent to the API as the cross product of their values, which is limited to 256 tag maps. Conflicts with `tag_rules`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The tag key. Each key can only be used by one tag rule.",
+						},
+						"operator": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      policyutil.TagRuleOperatorEquals,
+							ValidateFunc: validation.StringInSlice(policyutil.TagRuleOperators, false),
+							Description:  "How the tag value is matched. `equals` matches the single value in `values`, `any_of` matches any of the values. Matching on the key only is not supported, the Border0 API matches exact tag values. The default value is `equals`.",
+						},
+						"values": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The tag values to match.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -86,37 +148,89 @@ func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, m any) diag
 		return diagnostics.Error(err, "Failed to fetch policy")
 	}
 
-	rawPolicyData, err := json.Marshal(&policy.PolicyData)
+	policyData, err := normalizePolicyData(policy.PolicyData)
 	if err != nil {
-		return diagnostics.Error(err, "Failed to marshal policy data")
-	}
-	var pdIface any
-	if err := json.Unmarshal(rawPolicyData, &pdIface); err != nil {
 		return diagnostics.Error(err, "Failed to process policy data")
 	}
-	pruneNullValues(pdIface)
-	filteredPolicyData, err := json.Marshal(pdIface)
+
+	lintWarnings, err := lintPolicyData(policy.Version, policyData, policy.OrgWide)
 	if err != nil {
-		return diagnostics.Error(err, "Failed to marshal filtered policy data")
+		return diagnostics.Error(err, "Failed to lint policy data")
+	}
+	// references that no longer resolve fail the next plan, a refresh only warns about them so the
+	// policy can still be destroyed
+	var unresolvedReferences []string
+	if d.Get("validate_references").(bool) {
+		unresolvedReferences, err = unresolvedPolicyReferences(ctx, m, policy.Version, policyData)
+		if err != nil {
+			return diagnostics.Error(err, "Failed to validate policy references")
+		}
 	}
 
-	tagRulesSlice := make([]map[string]any, 0, len(policy.TagRules))
-	for _, rule := range policy.TagRules {
-		ruleMap := make(map[string]any)
-		for key, val := range rule {
-			ruleMap[key] = val
+	values := map[string]any{
+		"name":          policy.Name,
+		"policy_data":   policyData,
+		"description":   policy.Description,
+		"org_wide":      policy.OrgWide,
+		"version":       policy.Version,
+		"lint_warnings": lintWarnings,
+	}
+
+	// tag rules are compared regardless of their order, so the API reordering them doesn't cause diffs
+	if tagRule, ok := d.GetOk("tag_rule"); ok {
+		values["tag_rules"] = []any{}
+		current, err := expandTagRuleBlocks(tagRule)
+		if err != nil || !policyutil.EqualTagRules(current, policy.TagRules) {
+			if rules, ok := policyutil.CollapseTagRules(policy.TagRules); ok && len(rules) > 0 {
+				values["tag_rule"] = flattenTagRuleBlocks(rules)
+			} else {
+				// the tag rules can't be expressed as tag_rule blocks, so they are shown in tag_rules instead
+				values["tag_rule"] = []any{}
+				values["tag_rules"] = flattenTagRules(policy.TagRules)
+			}
 		}
-		tagRulesSlice = append(tagRulesSlice, ruleMap)
+	} else if current, err := mapTagRules(d.Get("tag_rules")); err != nil || !policyutil.EqualTagRules(current, policy.TagRules) {
+		values["tag_rules"] = flattenTagRules(policy.TagRules)
 	}
 
-	return schemautil.SetValues(d, map[string]any{
-		"name":        policy.Name,
-		"policy_data": string(filteredPolicyData),
-		"description": policy.Description,
-		"org_wide":    policy.OrgWide,
-		"version":     policy.Version,
-		"tag_rules":   tagRulesSlice,
-	})
+	// the structured policy_v2 blocks are only kept in state when they are used, and are left as
+	// they are when they describe the same policy data, e.g. with permissions that are not allowed
+	if policyV2, ok := d.GetOk("policy_v2"); ok && policy.Version == "v2" {
+		current, err := expandPolicyV2Block(policyV2.([]any))
+		if err != nil {
+			return diagnostics.Error(err, "Failed to process policy_v2")
+		}
+		// the policy data returned by the api is pruned, so the blocks are compared pruned as well
+		if pruneNullJSON(current) != policyData {
+			var pd border0client.PolicyDataV2
+			if err := json.Unmarshal([]byte(policyData), &pd); err != nil {
+				return diagnostics.Error(err, "Failed to unmarshal policy data")
+			}
+			values["policy_v2"] = []any{map[string]any{
+				"permissions": flattenPolicyV2Permissions(pd.Permissions),
+				"condition":   flattenPolicyV2Condition(pd.Condition),
+			}}
+		}
+	}
+
+	// custom diffs can't warn, so the lint warnings are reported when the policy is read instead,
+	// which is part of every plan that refreshes the state
+	diags := schemautil.SetValues(d, values)
+	for _, warning := range lintWarnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Policy (%s) lint warning", policy.Name),
+			Detail:   warning,
+		})
+	}
+	for _, reference := range unresolvedReferences {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Policy (%s) references principals that do not exist", policy.Name),
+			Detail:   reference,
+		})
+	}
+	return diags
 }
 
 func getResourcePolicyCreate(sem *semaphore.Weighted) schema.CreateContextFunc {
@@ -129,28 +243,22 @@ func getResourcePolicyCreate(sem *semaphore.Weighted) schema.CreateContextFunc {
 		helper := m.(*ProviderHelper)
 		client := helper.Requester
 
+		tagRules, err := policyTagRules(d)
+		if err != nil {
+			return diagnostics.Error(err, "Failed to process tag rules")
+		}
+
 		policy := &border0client.Policy{
 			Name:     d.Get("name").(string),
 			Version:  d.Get("version").(string),
-			TagRules: mapTagRules(d.Get("tag_rules")),
+			TagRules: tagRules,
 		}
 
-		switch policy.Version {
-		case "v1":
-			var policyData border0client.PolicyData
-			if err := json.Unmarshal([]byte(d.Get("policy_data").(string)), &policyData); err != nil {
-				return diagnostics.Error(err, "Failed to unmarshal policy data")
-			}
-			policy.PolicyData = policyData
-		case "v2":
-			var policyData border0client.PolicyDataV2
-			if err := json.Unmarshal([]byte(d.Get("policy_data").(string)), &policyData); err != nil {
-				return diagnostics.Error(err, "Failed to unmarshal policy data")
-			}
-			policy.PolicyData = policyData
-		default:
-			return diag.Errorf("Invalid policy version: %s", policy.Version)
+		policyData, _, err := expandPolicyData(policy.Version, d.Get("policy_data").(string))
+		if err != nil {
+			return diagnostics.Error(err, "Failed to unmarshal policy data")
 		}
+		policy.PolicyData = policyData
 
 		if v, ok := d.GetOk("description"); ok {
 			policy.Description = v.(string)
@@ -182,36 +290,29 @@ func getResourcePolicyUpdate(sem *semaphore.Weighted) schema.UpdateContextFunc {
 		client := helper.Requester
 
 		if d.HasChangesExcept("org_wide") {
+			tagRules, err := policyTagRules(d)
+			if err != nil {
+				return diagnostics.Error(err, "Failed to process tag rules")
+			}
+
 			policyUpdate := &border0client.Policy{
 				Name:     d.Get("name").(string),
-				TagRules: mapTagRules(d.Get("tag_rules")),
+				Version:  d.Get("version").(string),
+				TagRules: tagRules,
 			}
 
-			switch d.Get("version").(string) {
-			case "v1":
-				var policyData border0client.PolicyData
-				if err := json.Unmarshal([]byte(d.Get("policy_data").(string)), &policyData); err != nil {
-					return diagnostics.Error(err, "Failed to unmarshal policy data")
-				}
-				policyUpdate.Version = "v1"
-				policyUpdate.PolicyData = policyData
-			case "v2":
-				var policyData border0client.PolicyDataV2
-				if err := json.Unmarshal([]byte(d.Get("policy_data").(string)), &policyData); err != nil {
-					return diagnostics.Error(err, "Failed to unmarshal policy data")
-				}
-				policyUpdate.Version = "v2"
-				policyUpdate.PolicyData = policyData
-			default:
-				return diag.Errorf("Invalid policy version: %s", policyUpdate.Version)
+			// changing the version from v1 to v2 converts the v1 policy data in place
+			policyData, _, err := expandPolicyData(policyUpdate.Version, d.Get("policy_data").(string))
+			if err != nil {
+				return diagnostics.Error(err, "Failed to unmarshal policy data")
 			}
+			policyUpdate.PolicyData = policyData
 
 			if v, ok := d.GetOk("description"); ok {
 				policyUpdate.Description = v.(string)
 			}
 
-			_, err := client.UpdatePolicy(ctx, d.Id(), policyUpdate)
-			if err != nil {
+			if _, err := client.UpdatePolicy(ctx, d.Id(), policyUpdate); err != nil {
 				return diagnostics.Error(err, "Failed to update policy")
 			}
 		}
@@ -237,6 +338,286 @@ func getResourcePolicyDelete(sem *semaphore.Weighted) schema.DeleteContextFunc {
 	}
 }
 
+func resourcePolicyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	// the structured policy_v2 blocks are rendered into policy_data, so both are planned together
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("policy_v2").IsWhollyKnown() {
+		if err := d.SetNewComputed("policy_data"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("lint_warnings")
+	}
+	if policyV2 := d.Get("policy_v2").([]any); len(policyV2) > 0 {
+		if version := d.Get("version").(string); d.NewValueKnown("version") && version != "v2" {
+			return fmt.Errorf("policy_v2 requires version to be v2, got %q", version)
+		}
+		policyData, err := expandPolicyV2Block(policyV2)
+		if err != nil {
+			return err
+		}
+		if err := d.SetNew("policy_data", policyData); err != nil {
+			return err
+		}
+	}
+
+	if tagRule, ok := d.GetOk("tag_rule"); ok && d.NewValueKnown("tag_rule") {
+		if _, err := expandTagRuleBlocks(tagRule); err != nil {
+			return err
+		}
+	}
+
+	// policy data that is computed from other resources can only be checked once it is known
+	if !d.NewValueKnown("policy_data") || !d.NewValueKnown("version") || !d.NewValueKnown("org_wide") {
+		return d.SetNewComputed("lint_warnings")
+	}
+
+	version := d.Get("version").(string)
+	policyData := d.Get("policy_data").(string)
+	if err := validatePolicyData(version, policyData); err != nil {
+		return err
+	}
+	// a v1 policy is only converted in place when nothing is lost, the api would silently grant or
+	// revoke access otherwise
+	if _, notes, err := expandPolicyData(version, policyData); err == nil && len(notes) > 0 {
+		return fmt.Errorf("v1 policy_data can't be converted to v2 without changing what it allows, rewrite it as a v2 policy, e.g. with the border0_policy_v1_to_v2 data source:\n- %s", strings.Join(notes, "\n- "))
+	}
+
+	// principals that do not exist grant nothing, so they always fail the plan when they are checked
+	if d.Get("validate_references").(bool) {
+		unresolved, err := unresolvedPolicyReferences(ctx, m, version, policyData)
+		if err != nil {
+			return err
+		}
+		if len(unresolved) > 0 {
+			return fmt.Errorf("policy references principals that do not exist:\n- %s", strings.Join(unresolved, "\n- "))
+		}
+	}
+
+	warnings, err := lintPolicyData(version, policyData, d.Get("org_wide").(bool))
+	if err != nil {
+		return err
+	}
+	if len(warnings) > 0 && policyLintWarningsAsErrors(m) {
+		return fmt.Errorf("policy lint warnings are treated as errors:\n- %s", strings.Join(warnings, "\n- "))
+	}
+	for _, warning := range warnings {
+		log.Printf("[WARN] Policy (%s): %s", d.Get("name"), warning)
+	}
+
+	return d.SetNew("lint_warnings", warnings)
+}
+
+// lintPolicyData returns lint warnings for the given policy data, see policyutil.LintV1 and policyutil.LintV2.
+func lintPolicyData(version, policyData string, orgWide bool) ([]string, error) {
+	if version != "v1" && version != "v2" {
+		return nil, nil
+	}
+	pd, _, err := expandPolicyData(version, policyData)
+	if err != nil {
+		return nil, err
+	}
+	switch pd := pd.(type) {
+	case border0client.PolicyData:
+		return policyutil.LintV1(pd, orgWide), nil
+	case border0client.PolicyDataV2:
+		return policyutil.LintV2(pd, orgWide), nil
+	default:
+		return nil, nil
+	}
+}
+
+// unresolvedPolicyReferences checks the principals of the policy's who condition against the organization,
+// and returns a message listing the values of each kind of principal that does not exist. The users and
+// groups are listed once, the service accounts are fetched by name, as they can't be listed.
+func unresolvedPolicyReferences(ctx context.Context, m any, version, policyData string) ([]string, error) {
+	if version != "v1" && version != "v2" {
+		return nil, nil
+	}
+	pd, _, err := expandPolicyData(version, policyData)
+	if err != nil {
+		return nil, err
+	}
+
+	var who border0client.PolicyWhoV2
+	switch pd := pd.(type) {
+	case border0client.PolicyData:
+		who = border0client.PolicyWhoV2{
+			Email:          pd.Condition.Who.Email,
+			Group:          pd.Condition.Who.Group,
+			ServiceAccount: pd.Condition.Who.ServiceAccount,
+		}
+	case border0client.PolicyDataV2:
+		who = pd.Condition.Who
+	}
+
+	client := m.(border0client.Requester)
+	var unresolved []string
+
+	if len(who.Email) > 0 {
+		users, err := client.Users(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch users list: %w", err)
+		}
+		emails := map[string]bool{}
+		for _, user := range users.List {
+			emails[strings.ToLower(user.Email)] = true
+		}
+		if unknown := unknownReferences(who.Email, func(email string) bool { return emails[strings.ToLower(email)] }); unknown != "" {
+			unresolved = append(unresolved, fmt.Sprintf("who.email references users that are not in the organization: %s", unknown))
+		}
+	}
+
+	if len(who.Group) > 0 {
+		groups, err := client.Groups(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch groups list: %w", err)
+		}
+		groupIDs := map[string]bool{}
+		for _, group := range groups.List {
+			groupIDs[group.ID] = true
+		}
+		if unknown := unknownReferences(who.Group, func(id string) bool { return groupIDs[id] }); unknown != "" {
+			unresolved = append(unresolved, fmt.Sprintf("who.group references groups that do not exist: %s", unknown))
+		}
+	}
+
+	if len(who.ServiceAccount) > 0 {
+		serviceAccounts := map[string]bool{}
+		for _, name := range who.ServiceAccount {
+			if _, ok := serviceAccounts[name]; ok {
+				continue
+			}
+			_, err := client.ServiceAccount(ctx, name)
+			if err != nil && !border0client.NotFound(err) {
+				return nil, fmt.Errorf("failed to fetch service account %q: %w", name, err)
+			}
+			serviceAccounts[name] = err == nil
+		}
+		if unknown := unknownReferences(who.ServiceAccount, func(name string) bool { return serviceAccounts[name] }); unknown != "" {
+			unresolved = append(unresolved, fmt.Sprintf("who.service_account references service accounts that do not exist: %s", unknown))
+		}
+	}
+
+	return unresolved, nil
+}
+
+// unknownReferences returns the quoted values that do not exist, separated by commas.
+func unknownReferences(values []string, exists func(string) bool) string {
+	var unknown []string
+	for _, value := range values {
+		if !exists(value) {
+			unknown = append(unknown, fmt.Sprintf("%q", value))
+		}
+	}
+	return strings.Join(unknown, ", ")
+}
+
+// validatePolicyData checks the where and when conditions of the given policy data, see policyutil.ValidateCondition.
+func validatePolicyData(version, policyData string) error {
+	if version != "v1" && version != "v2" {
+		return nil
+	}
+	pd, _, err := expandPolicyData(version, policyData)
+	if err != nil {
+		return err
+	}
+
+	var where border0client.PolicyWhere
+	var when border0client.PolicyWhen
+	switch pd := pd.(type) {
+	case border0client.PolicyData:
+		where, when = pd.Condition.Where, pd.Condition.When
+	case border0client.PolicyDataV2:
+		where, when = pd.Condition.Where, pd.Condition.When
+	}
+
+	if errs := policyutil.ValidateCondition(where, when); len(errs) > 0 {
+		return fmt.Errorf("invalid policy_data condition: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// expandPolicyData decodes policy_data for the given policy version. A v1 document is converted when
+// the version is v2, so a v1 policy can be upgraded in place by only changing its version. The returned
+// notes describe the parts of a converted document that have no exact v2 mapping.
+func expandPolicyData(version, policyData string) (any, []string, error) {
+	switch version {
+	case "v1":
+		if isPolicyDocumentVersion(policyData, "v2") {
+			return nil, nil, errors.New("policy_data is a v2 policy document, converting v2 policies to v1 is not supported")
+		}
+		var pd border0client.PolicyData
+		if err := json.Unmarshal([]byte(policyData), &pd); err != nil {
+			return nil, nil, fmt.Errorf("policy_data is not a valid v1 policy: %w", err)
+		}
+		return pd, nil, nil
+	case "v2":
+		if isPolicyDocumentVersion(policyData, "v1") {
+			var pd border0client.PolicyData
+			if err := json.Unmarshal([]byte(policyData), &pd); err != nil {
+				return nil, nil, fmt.Errorf("policy_data is not a valid v1 policy: %w", err)
+			}
+			converted, notes := policyutil.ConvertV1ToV2(pd)
+			return converted, notes, nil
+		}
+		var pd border0client.PolicyDataV2
+		if err := json.Unmarshal([]byte(policyData), &pd); err != nil {
+			return nil, nil, fmt.Errorf("policy_data is not a valid v2 policy: %w", err)
+		}
+		return pd, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid policy version: %s", version)
+	}
+}
+
+// expandPolicyV2Block renders the structured policy_v2 blocks as policy data JSON. It isn't pruned
+// like the policy data returned by the api, an empty list e.g. of commands allows nothing, while
+// a missing one allows everything.
+func expandPolicyV2Block(policyV2 []any) (string, error) {
+	block, _ := policyV2[0].(map[string]any)
+	policyData, err := json.Marshal(expandPolicyV2Document(block["permissions"], block["condition"]))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal policy data: %w", err)
+	}
+	return string(policyData), nil
+}
+
+// listBlockSchema returns a copy of the given schema with nested blocks turned into lists,
+// so plans show per-field diffs instead of replacing whole blocks.
+func listBlockSchema(s *schema.Schema) *schema.Schema {
+	copied := *s
+	if elem, ok := s.Elem.(*schema.Resource); ok {
+		if copied.Type == schema.TypeSet {
+			copied.Type = schema.TypeList
+			copied.Set = nil
+		}
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = listBlockSchema(v)
+		}
+		copied.Elem = &schema.Resource{Schema: nested}
+	}
+	return &copied
+}
+
+// isPolicyDocumentVersion returns whether the policy document is written in the given version, going
+// by its top-level keys: v1 documents have an action list, v2 documents have a permissions object.
+func isPolicyDocumentVersion(policyData, version string) bool {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(policyData), &document); err != nil {
+		return false
+	}
+	_, hasAction := document["action"]
+	_, hasPermissions := document["permissions"]
+	switch version {
+	case "v1":
+		return hasAction && !hasPermissions
+	case "v2":
+		return hasPermissions && !hasAction
+	default:
+		return false
+	}
+}
+
 // suppressEquivalentPolicyDiffs suppresses spurious diffs in policy_data by checking
 // for semantic equivalence, ignoring default values and unordered arrays.
 func suppressEquivalentPolicyDiffs(k, old, new string, d *schema.ResourceData) bool {
@@ -249,25 +630,14 @@ func suppressEquivalentPolicyDiffs(k, old, new string, d *schema.ResourceData) b
 		return true
 	}
 
-	// first, unmarshal into typed structs to ignore default values
-	switch d.Get("version").(string) {
-	case "v1":
-		var oldPD, newPD border0client.PolicyData
-		if err := json.Unmarshal([]byte(old), &oldPD); err == nil {
-			if err := json.Unmarshal([]byte(new), &newPD); err == nil {
-				if reflect.DeepEqual(oldPD, newPD) {
-					return true
-				}
-			}
-		}
-	case "v2":
-		var oldPD, newPD border0client.PolicyDataV2
-		if err := json.Unmarshal([]byte(old), &oldPD); err == nil {
-			if err := json.Unmarshal([]byte(new), &newPD); err == nil {
-				if reflect.DeepEqual(oldPD, newPD) {
-					return true
-				}
-			}
+	// first, decode into typed structs to ignore default values, a v1
+	// document is compared by its conversion when the version is v2
+	switch version := d.Get("version").(string); version {
+	case "v1", "v2":
+		oldPD, _, oldErr := expandPolicyData(version, old)
+		newPD, _, newErr := expandPolicyData(version, new)
+		if oldErr == nil && newErr == nil && reflect.DeepEqual(oldPD, newPD) {
+			return true
 		}
 	default:
 		return false
@@ -279,28 +649,91 @@ func suppressEquivalentPolicyDiffs(k, old, new string, d *schema.ResourceData) b
 	return jsoneq.AreEqual(old, new)
 }
 
-func mapTagRules(tagRules any) []map[string]string {
-	if raw, ok := tagRules.([]any); ok {
-		if len(raw) == 0 {
-			return []map[string]string{}
-		}
-		rules := make([]map[string]string, 0, len(raw))
-		for _, rule := range raw {
-			if ruleMap, ok := rule.(map[string]any); ok {
-				stringMap := make(map[string]string)
-				for key, val := range ruleMap {
-					if strVal, ok := val.(string); ok {
-						stringMap[key] = strVal
-					} else {
-						log.Printf("[WARN] Expected string value for key '%s', got %T", key, val)
-					}
-				}
-				rules = append(rules, stringMap)
+func mapTagRules(tagRules any) ([]map[string]string, error) {
+	raw, _ := tagRules.([]any)
+	rules := make([]map[string]string, 0, len(raw))
+	for _, rule := range raw {
+		ruleMap, _ := rule.(map[string]any)
+		stringMap := make(map[string]string, len(ruleMap))
+		for key, val := range ruleMap {
+			str, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("tag rule value for key %q must be a string, got %T", key, val)
 			}
+			stringMap[key] = str
+		}
+		rules = append(rules, stringMap)
+	}
+	return rules, nil
+}
+
+// policyTagRules returns the tag rules of the policy, from the tag_rule blocks when they are used.
+func policyTagRules(d *schema.ResourceData) ([]map[string]string, error) {
+	if tagRule, ok := d.GetOk("tag_rule"); ok {
+		return expandTagRuleBlocks(tagRule)
+	}
+	return mapTagRules(d.Get("tag_rules"))
+}
+
+// expandTagRuleBlocks translates the tag_rule blocks into the tag maps of the API, see policyutil.ExpandTagRules.
+func expandTagRuleBlocks(tagRule any) ([]map[string]string, error) {
+	var rules []policyutil.TagRule
+	for _, v := range tagRule.(*schema.Set).List() {
+		block := v.(map[string]any)
+		rules = append(rules, policyutil.TagRule{
+			Key:      block["key"].(string),
+			Operator: block["operator"].(string),
+			Values:   policyDecodeStringList(block["values"].(*schema.Set).List()),
+		})
+	}
+	return policyutil.ExpandTagRules(rules)
+}
+
+func flattenTagRuleBlocks(rules []policyutil.TagRule) []any {
+	blocks := make([]any, 0, len(rules))
+	for _, rule := range rules {
+		values := make([]any, 0, len(rule.Values))
+		for _, value := range rule.Values {
+			values = append(values, value)
 		}
-		return rules
+		blocks = append(blocks, map[string]any{
+			"key":      rule.Key,
+			"operator": rule.Operator,
+			"values":   values,
+		})
+	}
+	return blocks
+}
+
+// normalizePolicyData renders policy data returned by the api as a JSON string,
+// with null and empty values pruned so it compares cleanly against configuration.
+func normalizePolicyData(policyData any) (string, error) {
+	rawPolicyData, err := json.Marshal(&policyData)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal policy data: %w", err)
+	}
+	var pdIface any
+	if err := json.Unmarshal(rawPolicyData, &pdIface); err != nil {
+		return "", err
+	}
+	pruneNullValues(pdIface)
+	filteredPolicyData, err := json.Marshal(pdIface)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal filtered policy data: %w", err)
+	}
+	return string(filteredPolicyData), nil
+}
+
+func flattenTagRules(tagRules []map[string]string) []map[string]any {
+	tagRulesSlice := make([]map[string]any, 0, len(tagRules))
+	for _, rule := range tagRules {
+		ruleMap := make(map[string]any)
+		for key, val := range rule {
+			ruleMap[key] = val
+		}
+		tagRulesSlice = append(tagRulesSlice, ruleMap)
 	}
-	return []map[string]string{}
+	return tagRulesSlice
 }
 
 func pruneNullValues(v any) {
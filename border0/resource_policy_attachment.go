@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
+	"slices"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
@@ -13,14 +13,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// policyAttachmentIDCodec encodes the ids of policy attachments.
+var policyAttachmentIDCodec = schemautil.NewIDCodec("policy_id", "socket_id")
+
 func resourcePolicyAttachment() *schema.Resource {
-	return &schema.Resource{
+	attachmentResource := &schema.Resource{
 		Description:   "Attaches a managed policy to a socket.",
+		SchemaVersion: 1,
 		ReadContext:   resourcePolicyAttachmentRead,
 		CreateContext: resourcePolicyAttachmentCreate,
 		DeleteContext: resourcePolicyAttachmentDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourcePolicyAttachmentImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"policy_id": {
@@ -37,18 +41,20 @@ func resourcePolicyAttachment() *schema.Resource {
 			},
 		},
 	}
+	attachmentResource.StateUpgraders = []schema.StateUpgrader{
+		policyAttachmentIDCodec.LegacyIDStateUpgrader(0, attachmentResource),
+	}
+	return attachmentResource
 }
 
 func resourcePolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := m.(border0client.Requester)
 
-	ids := strings.Split(d.Id(), ":")
-	if len(ids) != 2 {
-		return diag.Errorf("Invalid ID format: %s. Correct format is policyID:socketID", d.Id())
+	var policyID, socketID string
+	if diags := policyAttachmentIDCodec.Load(d, &policyID, &socketID); diags.HasError() {
+		return diags
 	}
 
-	policyID, socketID := ids[0], ids[1]
-
 	policy, err := client.Policy(ctx, policyID)
 	if !d.IsNewResource() && border0client.NotFound(err) {
 		log.Printf("[WARN] Policy (%s) not found, removing from state", policyID)
@@ -59,15 +65,18 @@ func resourcePolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, m
 		return diagnostics.Error(err, "Failed to fetch policy")
 	}
 
-	for _, eachSocketID := range policy.SocketIDs {
-		if eachSocketID == socketID {
-			return schemautil.SetValues(d, map[string]any{
-				"policy_id": policyID,
-				"socket_id": socketID,
-			})
-		}
+	// the socket is no longer listed when the policy was detached outside of terraform,
+	// or when the socket itself was deleted, in both cases the attachment is gone
+	if !d.IsNewResource() && !slices.Contains(policy.SocketIDs, socketID) {
+		log.Printf("[WARN] Policy (%s) no longer attached to socket (%s), removing from state", policyID, socketID)
+		d.SetId("")
+		return nil
 	}
-	return nil
+
+	return schemautil.SetValues(d, map[string]any{
+		"policy_id": policyID,
+		"socket_id": socketID,
+	})
 }
 
 func resourcePolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -80,7 +89,7 @@ func resourcePolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diagnostics.Error(err, "Failed to attach policy to socket")
 	}
-	d.SetId(fmt.Sprintf("%s:%s", policyID, socketID))
+	policyAttachmentIDCodec.Set(d, policyID, socketID)
 
 	helper.ReadAfterWriteDelay()
 	return resourcePolicyAttachmentRead(ctx, d, m)
@@ -88,14 +97,36 @@ func resourcePolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 
 func resourcePolicyAttachmentDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := m.(border0client.Requester)
-	ids := strings.Split(d.Id(), ":")
-	if len(ids) != 2 {
-		return diag.Errorf("Invalid ID format: %s. Correct format is policyID:socketID", d.Id())
+
+	var policyID, socketID string
+	if diags := policyAttachmentIDCodec.Load(d, &policyID, &socketID); diags.HasError() {
+		return diags
 	}
-	policyID, socketID := ids[0], ids[1]
-	if err := client.RemovePolicyFromSocket(ctx, policyID, socketID); err != nil {
+
+	// nothing left to detach if either the policy or the socket was already deleted
+	if err := client.RemovePolicyFromSocket(ctx, policyID, socketID); err != nil && !border0client.NotFound(err) {
 		return diagnostics.Error(err, "Failed to remove policy from socket")
 	}
 	d.SetId("")
 	return nil
 }
+
+func resourcePolicyAttachmentImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	client := m.(border0client.Requester)
+
+	parts, err := policyAttachmentIDCodec.Decode(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	policyID, socketID := parts[0], parts[1]
+
+	policy, err := client.Policy(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy %s: %w", policyID, err)
+	}
+	if !slices.Contains(policy.SocketIDs, socketID) {
+		return nil, fmt.Errorf("policy %s is not attached to socket %s", policyID, socketID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -1,6 +1,7 @@
 package border0_test
 
 import (
+	"regexp"
 	"testing"
 
 	border0client "github.com/borderzero/border0-go/client"
@@ -35,7 +36,11 @@ func Test_Resource_Border0PolicyAttachment(t *testing.T) {
 		clientMock.EXPECT().Policy(matchContext, policyID).Return(&policy, nil).Call,
 		clientMock.EXPECT().Policy(matchContext, policyID).Return(&policy, nil).Call,
 
-		// terraform import (read)
+		// terraform import (import + read)
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&policy, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&policy, nil).Call,
+
+		// terraform import of a pair that is not attached (import)
 		clientMock.EXPECT().Policy(matchContext, policyID).Return(&policy, nil).Call,
 
 		// terraform destroy (delete)
@@ -51,7 +56,7 @@ func Test_Resource_Border0PolicyAttachment(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("border0_policy_attachment.unit_test", "policy_id", "unit-test-policy-id"),
 					resource.TestCheckResourceAttr("border0_policy_attachment.unit_test", "socket_id", "unit-test-socket-id"),
-					resource.TestCheckResourceAttrSet("border0_policy_attachment.unit_test", "id"),
+					resource.TestCheckResourceAttr("border0_policy_attachment.unit_test", "id", "unit-test-policy-id:unit-test-socket-id"),
 				),
 			},
 			{
@@ -59,6 +64,61 @@ func Test_Resource_Border0PolicyAttachment(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:  "border0_policy_attachment.unit_test",
+				ImportState:   true,
+				ImportStateId: "unit-test-policy-id:not-attached-socket-id",
+				ExpectError:   regexp.MustCompile("policy unit-test-policy-id is not attached to socket not-attached-socket-id"),
+			},
+			{
+				ResourceName:  "border0_policy_attachment.unit_test",
+				ImportState:   true,
+				ImportStateId: "unit-test-policy-id",
+				ExpectError:   regexp.MustCompile("expected format is policy_id:socket_id"),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0PolicyAttachment_DetachedOutOfBand(t *testing.T) {
+	policyID := "unit-test-policy-id"
+	socketID := "unit-test-socket-id"
+
+	attached := border0client.Policy{SocketIDs: []string{socketID}}
+	detached := border0client.Policy{SocketIDs: []string{}}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create + read + read)
+		clientMock.EXPECT().AttachPolicyToSocket(matchContext, policyID, socketID).Return(nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&attached, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&attached, nil).Call,
+
+		// refresh finds the policy detached outside of terraform, so it is removed from state...
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&detached, nil).Call,
+
+		// ...and terraform apply re-attaches it (create + read + read)
+		clientMock.EXPECT().AttachPolicyToSocket(matchContext, policyID, socketID).Return(nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&attached, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&attached, nil).Call,
+
+		// terraform destroy (delete), the socket was deleted in the meantime
+		clientMock.EXPECT().RemovePolicyFromSocket(matchContext, policyID, socketID).Return(border0client.Error{Code: 404, Message: "socket not found"}).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: policyAttachmentConfig,
+			},
+			{
+				Config: policyAttachmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy_attachment.unit_test", "id", "unit-test-policy-id:unit-test-socket-id"),
+				),
+			},
 		},
 	})
 }
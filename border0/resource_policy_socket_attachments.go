@@ -0,0 +1,143 @@
+package border0
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil/schemaconvert"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/sync/errgroup"
+)
+
+func resourcePolicySocketAttachments() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Authoritatively manages the complete set of sockets a policy is attached to. Sockets attached to the policy that are not declared in `socket_ids` are detached. Do not combine with `border0_policy_attachment` resources for the same policy.",
+		ReadContext:   resourcePolicySocketAttachmentsRead,
+		CreateContext: resourcePolicySocketAttachmentsCreate,
+		UpdateContext: resourcePolicySocketAttachmentsUpdate,
+		DeleteContext: resourcePolicySocketAttachmentsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the policy.",
+			},
+			"socket_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The complete set of IDs of the sockets the policy is attached to.",
+			},
+		},
+	}
+}
+
+func resourcePolicySocketAttachmentsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	policy, err := client.Policy(ctx, d.Id())
+	if !d.IsNewResource() && border0client.NotFound(err) {
+		// in case if the policy was deleted without Terraform knowing about it, we need to remove it from the state
+		log.Printf("[WARN] Policy (%s) not found, removing socket attachments from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch policy")
+	}
+
+	socketIDs := append([]string{}, policy.SocketIDs...)
+	sort.Strings(socketIDs)
+
+	return schemautil.SetValues(d, map[string]any{
+		"policy_id":  d.Id(),
+		"socket_ids": socketIDs,
+	})
+}
+
+func resourcePolicySocketAttachmentsCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	helper := m.(*ProviderHelper)
+
+	policyID := d.Get("policy_id").(string)
+	desired := schemaconvert.SetToSlice[string](d.Get("socket_ids").(*schema.Set))
+	if diags := reconcilePolicySockets(ctx, helper.Requester, policyID, desired); diags.HasError() {
+		return diags
+	}
+	d.SetId(policyID)
+
+	helper.ReadAfterWriteDelay()
+	return resourcePolicySocketAttachmentsRead(ctx, d, m)
+}
+
+func resourcePolicySocketAttachmentsUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	helper := m.(*ProviderHelper)
+
+	if d.HasChange("socket_ids") {
+		desired := schemaconvert.SetToSlice[string](d.Get("socket_ids").(*schema.Set))
+		if diags := reconcilePolicySockets(ctx, helper.Requester, d.Id(), desired); diags.HasError() {
+			return diags
+		}
+		helper.ReadAfterWriteDelay()
+	}
+
+	return resourcePolicySocketAttachmentsRead(ctx, d, m)
+}
+
+func resourcePolicySocketAttachmentsDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	socketIDs := schemaconvert.SetToSlice[string](d.Get("socket_ids").(*schema.Set))
+	if err := forEachConcurrently(ctx, socketIDs, func(ctx context.Context, socketID string) error {
+		err := client.RemovePolicyFromSocket(ctx, d.Id(), socketID)
+		if border0client.NotFound(err) {
+			return nil
+		}
+		return err
+	}); err != nil {
+		return diagnostics.Error(err, "Failed to remove policy from sockets")
+	}
+	d.SetId("")
+	return nil
+}
+
+// reconcilePolicySockets makes the set of sockets a policy is attached to match the desired
+// socket ids, attaching and detaching the difference against the current attachments.
+func reconcilePolicySockets(ctx context.Context, client border0client.Requester, policyID string, desired []string) diag.Diagnostics {
+	policy, err := client.Policy(ctx, policyID)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch policy")
+	}
+
+	toAttach, toRemove := diffStringSlices(policy.SocketIDs, desired)
+	if err := forEachConcurrently(ctx, toRemove, func(ctx context.Context, socketID string) error {
+		return client.RemovePolicyFromSocket(ctx, policyID, socketID)
+	}); err != nil {
+		return diagnostics.Error(err, "Failed to remove policy from sockets")
+	}
+	if err := forEachConcurrently(ctx, toAttach, func(ctx context.Context, socketID string) error {
+		return client.AttachPolicyToSocket(ctx, policyID, socketID)
+	}); err != nil {
+		return diagnostics.Error(err, "Failed to attach policy to sockets")
+	}
+	return nil
+}
+
+// forEachConcurrently calls fn for each of the given items, with at most maxParallelism calls in flight.
+// The first error returned by fn cancels the remaining calls and is returned.
+func forEachConcurrently[T any](ctx context.Context, items []T, fn func(context.Context, T) error) error {
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(maxParallelism)
+	for _, item := range items {
+		group.Go(func() error { return fn(ctx, item) })
+	}
+	return group.Wait()
+}
@@ -0,0 +1,86 @@
+package border0_test
+
+import (
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+var policySocketAttachmentsConfig = `
+resource "border0_policy_socket_attachments" "unit_test" {
+  policy_id  = "unit-test-policy-id"
+  socket_ids = [ "unit-test-socket-id-1" ]
+}
+`
+
+var policySocketAttachmentsConfig_update = `
+resource "border0_policy_socket_attachments" "unit_test" {
+  policy_id  = "unit-test-policy-id"
+  socket_ids = [ "unit-test-socket-id-2" ]
+}
+`
+
+func Test_Resource_Border0PolicySocketAttachments(t *testing.T) {
+	policyID := "unit-test-policy-id"
+
+	// unit-test-socket-id-manual was attached outside of terraform
+	existing := border0client.Policy{ID: policyID, SocketIDs: []string{"unit-test-socket-id-manual"}}
+	initial := border0client.Policy{ID: policyID, SocketIDs: []string{"unit-test-socket-id-1"}}
+	updated := border0client.Policy{ID: policyID, SocketIDs: []string{"unit-test-socket-id-2"}}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (reconcile + read + read)
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&existing, nil).Call,
+		clientMock.EXPECT().RemovePolicyFromSocket(matchContext, policyID, "unit-test-socket-id-manual").Return(nil).Call,
+		clientMock.EXPECT().AttachPolicyToSocket(matchContext, policyID, "unit-test-socket-id-1").Return(nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&initial, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&initial, nil).Call,
+
+		// this read is needed because of the update
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&initial, nil).Call,
+
+		// terraform apply (reconcile + read + read)
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&initial, nil).Call,
+		clientMock.EXPECT().RemovePolicyFromSocket(matchContext, policyID, "unit-test-socket-id-1").Return(nil).Call,
+		clientMock.EXPECT().AttachPolicyToSocket(matchContext, policyID, "unit-test-socket-id-2").Return(nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&updated, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&updated, nil).Call,
+
+		// terraform import (read)
+		clientMock.EXPECT().Policy(matchContext, policyID).Return(&updated, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().RemovePolicyFromSocket(matchContext, policyID, "unit-test-socket-id-2").Return(nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: policySocketAttachmentsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy_socket_attachments.unit_test", "policy_id", policyID),
+					resource.TestCheckResourceAttr("border0_policy_socket_attachments.unit_test", "socket_ids.#", "1"),
+					resource.TestCheckResourceAttr("border0_policy_socket_attachments.unit_test", "socket_ids.0", "unit-test-socket-id-1"),
+					resource.TestCheckResourceAttr("border0_policy_socket_attachments.unit_test", "id", policyID),
+				),
+			},
+			{
+				Config: policySocketAttachmentsConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy_socket_attachments.unit_test", "socket_ids.#", "1"),
+					resource.TestCheckResourceAttr("border0_policy_socket_attachments.unit_test", "socket_ids.0", "unit-test-socket-id-2"),
+				),
+			},
+			{
+				ResourceName:      "border0_policy_socket_attachments.unit_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -3,11 +3,16 @@ package border0_test
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
 	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/border0"
 	"github.com/borderzero/terraform-provider-border0/mocks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -674,3 +679,723 @@ func toJSON(v any) string {
 	b, _ := json.Marshal(v)
 	return string(b)
 }
+
+func Test_Resource_Border0Policy_InvalidCondition(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "border0_policy" "unit_test" {
+						name        = "unit-test-policy"
+						policy_data = jsonencode({
+							"permissions" : { "http" : {} },
+							"condition" : {
+								"who" : { "email" : [ "johndoe@example.com" ] },
+								"where" : { "allowed_ip" : [ "10.0.0.0/33" ] },
+								"when" : {}
+							}
+						})
+					}`,
+				ExpectError: regexp.MustCompile(`"10.0.0.0/33" is not a valid IP address or CIDR block`),
+			},
+			{
+				Config: `
+					resource "border0_policy" "unit_test" {
+						name        = "unit-test-policy"
+						version     = "v1"
+						policy_data = jsonencode({
+							"version" : "v1",
+							"action" : [ "http" ],
+							"condition" : {
+								"who" : { "email" : [ "johndoe@example.com" ] },
+								"where" : { "country" : [ "NL" ], "country_not" : [ "NL" ] },
+								"when" : { "after" : "2024-01-01T00:00:00Z", "before" : "2023-01-01T00:00:00Z" }
+							}
+						})
+					}`,
+				ExpectError: regexp.MustCompile(`(?s)country "NL" is listed in both country and country_not.*must be earlier than before`),
+			},
+		},
+	})
+}
+
+var lintedPolicyConfig = `
+resource "border0_policy" "unit_test" {
+  name        = "unit-test-policy"
+  policy_data = jsonencode({
+    "permissions" : {
+      "ssh" : {
+        "exec" : {},
+        "max_session_duration_seconds" : 3600
+      }
+    },
+    "condition" : {
+      "who" : { "email" : [ "johndoe@example.com" ] },
+      "where" : { "allowed_ip" : [ "10.0.0.0/8" ] },
+      "when" : {}
+    }
+  })
+}
+`
+
+func Test_Resource_Border0Policy_LintWarningsAsErrors(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: testProviderFactoriesWithHelper(t, &border0.ProviderHelper{
+			Requester:                  new(mocks.APIClientRequester),
+			Delayer:                    &border0.NoopDelayer{},
+			PolicyLintWarningsAsErrors: true,
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config:      lintedPolicyConfig,
+				ExpectError: regexp.MustCompile(`policy lint warnings are treated as errors:\s+- ssh exec is allowed without a commands list`),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Policy_LintWarnings(t *testing.T) {
+	policy := border0client.Policy{
+		ID:      "unit-test-id-1",
+		Name:    "unit-test-policy",
+		Version: "v2",
+		PolicyData: border0client.PolicyDataV2{
+			Permissions: border0client.PolicyPermissions{
+				SSH: &border0client.SSHPermissions{
+					Exec:                      &border0client.SSHExecPermission{},
+					MaxSessionDurationSeconds: &[]int{3600}[0],
+				},
+			},
+			Condition: border0client.PolicyConditionV2{
+				Who:   border0client.PolicyWhoV2{Email: []string{"johndoe@example.com"}},
+				Where: border0client.PolicyWhere{AllowedIP: []string{"10.0.0.0/8"}},
+			},
+		},
+	}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create + read + read)
+		clientMock.EXPECT().CreatePolicy(matchContext, mock.AnythingOfType("*client.Policy")).Return(&policy, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&policy, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&policy, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeletePolicy(matchContext, "unit-test-id-1").Return(nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: lintedPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "lint_warnings.#", "1"),
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "lint_warnings.0", "ssh exec is allowed without a commands list, any command can be executed"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Policy_ChangeVersionV1ToV2(t *testing.T) {
+	v1PolicyData := border0client.PolicyData{
+		Version: "v1",
+		Action:  []string{"database", "ssh", "http", "tls"},
+		Condition: border0client.PolicyCondition{
+			Who: border0client.PolicyWho{
+				Email:  []string{"johndoe@example.com"},
+				Group:  []string{"db5c2352-b689-4135-babc-e97a8893128b"},
+				Domain: []string{"example.com"},
+			},
+			Where: border0client.PolicyWhere{
+				AllowedIP:  []string{"0.0.0.0/0", "::/0"},
+				Country:    []string{"NL", "CA", "US", "BR", "FR"},
+				CountryNot: []string{"BE"},
+			},
+			When: border0client.PolicyWhen{
+				After:           "2022-10-13T05:12:26Z",
+				TimeOfDayAfter:  "00:00 UTC",
+				TimeOfDayBefore: "23:59 UTC",
+			},
+		},
+	}
+	// the v1 policy data gets converted, without who.domain, which has no v2 equivalent
+	v2PolicyData := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Database: &border0client.DatabasePermissions{},
+			SSH: &border0client.SSHPermissions{
+				Shell:         &border0client.SSHShellPermission{},
+				Exec:          &border0client.SSHExecPermission{},
+				SFTP:          &border0client.SSHSFTPPermission{},
+				TCPForwarding: &border0client.SSHTCPForwardingPermission{},
+				KubectlExec:   &border0client.SSHKubectlExecPermission{},
+				DockerExec:    &border0client.SSHDockerExecPermission{},
+			},
+			HTTP: &border0client.HTTPPermissions{},
+			TLS:  &border0client.TLSPermissions{},
+		},
+		Condition: border0client.PolicyConditionV2{
+			Who: border0client.PolicyWhoV2{
+				Email: []string{"johndoe@example.com"},
+				Group: []string{"db5c2352-b689-4135-babc-e97a8893128b"},
+			},
+			Where: v1PolicyData.Condition.Where,
+			When:  v1PolicyData.Condition.When,
+		},
+	}
+
+	initialInput := border0client.Policy{
+		Name:        "unit-test-policy-1",
+		Description: "policy created from terraform unit test",
+		Version:     "v1",
+		PolicyData:  v1PolicyData,
+		TagRules:    []map[string]string{},
+	}
+	initialOutput := border0client.Policy{
+		ID:          "unit-test-id-1",
+		Version:     "v1",
+		Name:        "unit-test-policy-1",
+		Description: "policy created from terraform unit test",
+		PolicyData:  v1PolicyData,
+		TagRules:    []map[string]string{},
+	}
+	updateInput := border0client.Policy{
+		Name:        "unit-test-policy-1",
+		Description: "policy created from terraform unit test",
+		Version:     "v2",
+		PolicyData:  v2PolicyData,
+		TagRules:    []map[string]string{},
+	}
+	updateOutput := border0client.Policy{
+		ID:          "unit-test-id-1",
+		Version:     "v2",
+		Name:        "unit-test-policy-1",
+		Description: "policy created from terraform unit test",
+		PolicyData:  v2PolicyData,
+		TagRules:    []map[string]string{},
+	}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create + read + read)
+		clientMock.EXPECT().CreatePolicy(matchContext, &initialInput).Return(&initialOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&initialOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&initialOutput, nil).Call,
+
+		// this read is needed because of the failed plan
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&initialOutput, nil).Call,
+
+		// this read is needed because of the update
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&initialOutput, nil).Call,
+
+		// terraform apply (update + read + read), only the version changes
+		clientMock.EXPECT().UpdatePolicy(matchContext, "unit-test-id-1", &updateInput).Return(&updateOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&updateOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&updateOutput, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeletePolicy(matchContext, "unit-test-id-1").Return(nil).Call,
+	)
+
+	v2PolicyDataJSON, err := json.Marshal(v2PolicyData)
+	require.NoError(t, err)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: initialPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "version", "v1"),
+				),
+			},
+			{
+				// who.domain would be dropped by the conversion, so the plan fails
+				Config:      strings.Replace(initialPolicyConfig, `version = "v1"`, `version = "v2"`, 1),
+				ExpectError: regexp.MustCompile(`who.domain \["example.com"\] has no v2 equivalent and was dropped`),
+			},
+			{
+				// the v1 policy_data is otherwise left as is, and compared by its conversion from now on
+				Config: strings.Replace(strings.Replace(initialPolicyConfig, `version = "v1"`, `version = "v2"`, 1), `,
+        "domain": [ "example.com" ]`, "", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "version", "v2"),
+					testMatchResourceAttrJSON("border0_policy.unit_test", "policy_data", string(v2PolicyDataJSON)),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Policy_ChangeVersionV2ToV1(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(initialPolicyConfigV2, `version = "v2"`, `version = "v1"`, 1),
+				ExpectError: regexp.MustCompile(`converting v2 policies to v1 is not supported`),
+			},
+		},
+	})
+}
+
+var policyV2BlockConfig = `
+resource "border0_policy" "unit_test" {
+  name = "unit-test-policy"
+  policy_v2 {
+    permissions {
+      ssh {
+        allowed                      = true
+        max_session_duration_seconds = %d
+        shell {
+          allowed = true
+        }
+      }
+      http {
+        allowed = false
+      }
+    }
+    condition {
+      who {
+        email = [ "johndoe@example.com" ]
+      }
+      where {
+        allowed_ip = [ "10.0.0.0/8" ]
+      }
+      when {}
+    }
+  }
+}
+`
+
+func Test_Resource_Border0Policy_PolicyV2Block(t *testing.T) {
+	policyData := func(maxSessionDurationSeconds int) border0client.PolicyDataV2 {
+		return border0client.PolicyDataV2{
+			Permissions: border0client.PolicyPermissions{
+				SSH: &border0client.SSHPermissions{
+					Shell:                     &border0client.SSHShellPermission{},
+					MaxSessionDurationSeconds: &maxSessionDurationSeconds,
+				},
+			},
+			Condition: border0client.PolicyConditionV2{
+				Who:   border0client.PolicyWhoV2{Email: []string{"johndoe@example.com"}},
+				Where: border0client.PolicyWhere{AllowedIP: []string{"10.0.0.0/8"}},
+			},
+		}
+	}
+	initialInput := border0client.Policy{
+		Name:       "unit-test-policy",
+		Version:    "v2",
+		PolicyData: policyData(3600),
+		TagRules:   []map[string]string{},
+	}
+	initialOutput := border0client.Policy{
+		ID:         "unit-test-id-1",
+		Name:       "unit-test-policy",
+		Version:    "v2",
+		PolicyData: policyData(3600),
+		TagRules:   []map[string]string{},
+	}
+	updateInput := border0client.Policy{
+		Name:       "unit-test-policy",
+		Version:    "v2",
+		PolicyData: policyData(7200),
+		TagRules:   []map[string]string{},
+	}
+	updateOutput := border0client.Policy{
+		ID:         "unit-test-id-1",
+		Name:       "unit-test-policy",
+		Version:    "v2",
+		PolicyData: policyData(7200),
+		TagRules:   []map[string]string{},
+	}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create + read + read)
+		clientMock.EXPECT().CreatePolicy(matchContext, &initialInput).Return(&initialOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&initialOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&initialOutput, nil).Call,
+
+		// this read is needed because of the update
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&initialOutput, nil).Call,
+
+		// terraform apply (update + read + read)
+		clientMock.EXPECT().UpdatePolicy(matchContext, "unit-test-id-1", &updateInput).Return(&updateOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&updateOutput, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&updateOutput, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeletePolicy(matchContext, "unit-test-id-1").Return(nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(policyV2BlockConfig, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "policy_v2.0.permissions.0.ssh.0.max_session_duration_seconds", "3600"),
+					// permissions that are not allowed are kept as configured
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "policy_v2.0.permissions.0.http.0.allowed", "false"),
+					testMatchResourceAttrJSON("border0_policy.unit_test", "policy_data", `{
+						"permissions": { "ssh": { "max_session_duration_seconds": 3600, "shell": {} } },
+						"condition": { "who": { "email": [ "johndoe@example.com" ] }, "where": { "allowed_ip": [ "10.0.0.0/8" ] }, "when": {} }
+					}`),
+				),
+			},
+			{
+				Config: fmt.Sprintf(policyV2BlockConfig, 7200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "policy_v2.0.permissions.0.ssh.0.max_session_duration_seconds", "7200"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Policy_PolicyV2BlockEmptyList(t *testing.T) {
+	// an empty list of commands allows no commands at all, so it must reach the api as is
+	policy := border0client.Policy{
+		Name:    "unit-test-policy",
+		Version: "v2",
+		PolicyData: border0client.PolicyDataV2{
+			Permissions: border0client.PolicyPermissions{
+				SSH: &border0client.SSHPermissions{
+					Exec: &border0client.SSHExecPermission{Commands: &[]string{}},
+				},
+			},
+			Condition: border0client.PolicyConditionV2{
+				Who: border0client.PolicyWhoV2{Email: []string{"johndoe@example.com"}},
+			},
+		},
+		TagRules: []map[string]string{},
+	}
+	output := policy
+	output.ID = "unit-test-id-1"
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create + read + read)
+		clientMock.EXPECT().CreatePolicy(matchContext, &policy).Return(&output, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&output, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&output, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeletePolicy(matchContext, "unit-test-id-1").Return(nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "border0_policy" "unit_test" {
+					name = "unit-test-policy"
+					policy_v2 {
+						permissions {
+							ssh {
+								allowed = true
+								exec {
+									allowed           = true
+									use_commands_list = true
+									commands          = []
+								}
+							}
+						}
+						condition {
+							who {
+								email = [ "johndoe@example.com" ]
+							}
+						}
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "policy_v2.0.permissions.0.ssh.0.exec.0.use_commands_list", "true"),
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "policy_v2.0.permissions.0.ssh.0.exec.0.commands.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Policy_PolicyV2BlockConflicts(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "border0_policy" "unit_test" {
+						name        = "unit-test-policy"
+						policy_data = jsonencode({})
+						policy_v2 {
+							condition {
+								who {}
+								where {}
+								when {}
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`"policy_data": only one of` + "`policy_data,policy_v2`" + ` can be specified`),
+			},
+			{
+				Config: `
+					resource "border0_policy" "unit_test" {
+						name    = "unit-test-policy"
+						version = "v1"
+						policy_v2 {
+							condition {
+								who {}
+								where {}
+								when {}
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`policy_v2 requires version to be v2, got "v1"`),
+			},
+		},
+	})
+}
+
+var referencesPolicyConfig = `
+resource "border0_policy" "unit_test" {
+  name                = "unit-test-policy"
+  validate_references = true
+  policy_data = jsonencode({
+    "permissions" : { "http" : {} },
+    "condition" : {
+      "who" : {
+        "email" : [ "johndoe@example.com", "nobody@example.com" ],
+        "group" : [ "unit-test-group-id-1", "unit-test-group-id-deleted" ],
+        "service_account" : [ "unit-test-service-account", "unit-test-service-account-deleted" ]
+      },
+      "where" : {},
+      "when" : {}
+    }
+  })
+}
+`
+
+var resolvedReferencesPolicyConfig = `
+resource "border0_policy" "unit_test" {
+  name                = "unit-test-policy"
+  validate_references = true
+  policy_data = jsonencode({
+    "permissions" : { "http" : {} },
+    "condition" : {
+      "who" : {
+        "email" : [ "johndoe@example.com" ],
+        "group" : [ "unit-test-group-id-1" ],
+        "service_account" : [ "unit-test-service-account" ]
+      },
+      "where" : {},
+      "when" : {}
+    }
+  })
+}
+`
+
+func referencesClientMock() *mocks.APIClientRequester {
+	clientMock := new(mocks.APIClientRequester)
+	clientMock.EXPECT().Users(matchContext).Return(&border0client.Users{List: []border0client.User{
+		{ID: "unit-test-user-id-1", Email: "JohnDoe@example.com"},
+	}}, nil)
+	clientMock.EXPECT().Groups(matchContext).Return(&border0client.Groups{List: []border0client.Group{
+		{ID: "unit-test-group-id-1", DisplayName: "engineering"},
+	}}, nil)
+	clientMock.EXPECT().ServiceAccount(matchContext, "unit-test-service-account").Return(&border0client.ServiceAccount{
+		Name: "unit-test-service-account",
+	}, nil)
+	clientMock.EXPECT().ServiceAccount(matchContext, "unit-test-service-account-deleted").Return(nil, border0client.Error{
+		Code:    http.StatusNotFound,
+		Message: "service account not found",
+	})
+	return clientMock
+}
+
+func Test_Resource_Border0Policy_ValidateReferences(t *testing.T) {
+	policy := border0client.Policy{
+		ID:      "unit-test-id-1",
+		Name:    "unit-test-policy",
+		Version: "v2",
+		PolicyData: border0client.PolicyDataV2{
+			Permissions: border0client.PolicyPermissions{
+				HTTP: &border0client.HTTPPermissions{},
+			},
+			Condition: border0client.PolicyConditionV2{
+				Who: border0client.PolicyWhoV2{
+					Email:          []string{"johndoe@example.com"},
+					Group:          []string{"unit-test-group-id-1"},
+					ServiceAccount: []string{"unit-test-service-account"},
+				},
+			},
+		},
+	}
+
+	// the principals are checked on every plan and refresh
+	clientMock := referencesClientMock()
+	mockCallsInOrder(
+		// terraform apply (create + read + read)
+		clientMock.EXPECT().CreatePolicy(matchContext, mock.AnythingOfType("*client.Policy")).Return(&policy, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&policy, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&policy, nil).Call,
+
+		// this read is needed because of the failed plan
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-1").Return(&policy, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeletePolicy(matchContext, "unit-test-id-1").Return(nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: resolvedReferencesPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test", "lint_warnings.#", "0"),
+				),
+			},
+			{
+				// principals that do not exist fail the plan, without policy_lint_warnings_as_errors
+				Config: referencesPolicyConfig,
+				ExpectError: regexp.MustCompile(`policy references principals that do not exist:\s+` +
+					`- who.email references users that are not in the organization: "nobody@example.com"\s+` +
+					`- who.group references groups that do not exist: "unit-test-group-id-deleted"\s+` +
+					`- who.service_account references service accounts that do not exist: "unit-test-service-account-deleted"`),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Policy_TagRule(t *testing.T) {
+	input := &border0client.Policy{
+		Name:       "unit-test-policy-tags",
+		Version:    "v2",
+		PolicyData: border0client.PolicyDataV2{},
+		TagRules: []map[string]string{
+			{"environment": "production", "team": "backend"},
+			{"environment": "staging", "team": "backend"},
+		},
+	}
+	// the API returns the tag rules in a different order
+	output := &border0client.Policy{
+		ID:         "unit-test-id-tags",
+		Name:       "unit-test-policy-tags",
+		Version:    "v2",
+		PolicyData: border0client.PolicyDataV2{},
+		TagRules: []map[string]string{
+			{"team": "backend", "environment": "staging"},
+			{"team": "backend", "environment": "production"},
+		},
+	}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create + read + read)
+		clientMock.EXPECT().CreatePolicy(matchContext, input).Return(output, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-tags").Return(output, nil).Call,
+		clientMock.EXPECT().Policy(matchContext, "unit-test-id-tags").Return(output, nil).Call,
+
+		// terraform destroy (delete)
+		clientMock.EXPECT().DeletePolicy(matchContext, "unit-test-id-tags").Return(nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "border0_policy" "unit_test_tags" {
+						name        = "unit-test-policy-tags"
+						policy_data = jsonencode({})
+						tag_rule {
+							key    = "team"
+							values = [ "backend" ]
+						}
+						tag_rule {
+							key      = "environment"
+							operator = "any_of"
+							values   = [ "staging", "production" ]
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_policy.unit_test_tags", "tag_rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("border0_policy.unit_test_tags", "tag_rule.*", map[string]string{
+						"key":      "team",
+						"operator": "equals",
+						"values.#": "1",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("border0_policy.unit_test_tags", "tag_rule.*", map[string]string{
+						"key":      "environment",
+						"operator": "any_of",
+						"values.#": "2",
+					}),
+					resource.TestCheckResourceAttr("border0_policy.unit_test_tags", "tag_rules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Policy_TagRuleInvalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, new(mocks.APIClientRequester)),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "border0_policy" "unit_test_tags" {
+						name        = "unit-test-policy-tags"
+						policy_data = jsonencode({})
+						tag_rule {
+							key    = "environment"
+							values = [ "staging", "production" ]
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`tag rule for key "environment" with the equals operator must have exactly one value, got 2`),
+			},
+			{
+				Config: `
+					resource "border0_policy" "unit_test_tags" {
+						name        = "unit-test-policy-tags"
+						policy_data = jsonencode({})
+						tag_rule {
+							key    = "environment"
+							values = [ "staging" ]
+						}
+						tag_rule {
+							key    = "environment"
+							values = [ "production" ]
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`tag key "environment" is used by more than one tag rule`),
+			},
+			{
+				Config: `
+					resource "border0_policy" "unit_test_tags" {
+						name        = "unit-test-policy-tags"
+						policy_data = jsonencode({})
+						tag_rules   = [{ environment = "staging" }]
+						tag_rule {
+							key    = "environment"
+							values = [ "staging" ]
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`"tag_rule": conflicts with tag_rules`),
+			},
+		},
+	})
+}
@@ -18,6 +18,7 @@ func resourceServiceAccount() *schema.Resource {
 		CreateContext: resourceServiceAccountCreate,
 		UpdateContext: resourceServiceAccountUpdate,
 		DeleteContext: resourceServiceAccountDelete,
+		CustomizeDiff: customizeDiffRole,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -30,7 +31,7 @@ func resourceServiceAccount() *schema.Resource {
 			"role": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The role for the service account. Currently valid values include 'admin', 'member', 'read only', and 'client'.",
+				Description: "The role for the service account. Currently valid values include 'admin', 'member', 'read only', and 'client', see the `border0_roles` data source for the roles of your organization.",
 			},
 			"description": {
 				Type:        schema.TypeString,
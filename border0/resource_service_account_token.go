@@ -2,7 +2,10 @@ package border0
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"maps"
+	"time"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
@@ -11,14 +14,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// serviceAccountTokenIDCodec encodes the ids of service account tokens. Legacy ids had the token id
+// first, unlike the ids of all other tokens and attachments.
+var serviceAccountTokenIDCodec = schemautil.NewIDCodec("service_account_name", "token_id")
+
 func resourceServiceAccountToken() *schema.Resource {
-	return &schema.Resource{
-		Description:   "The service account token resource allows you to create and delete a token for a Border0 service account.",
+	tokenResource := &schema.Resource{
+		Description:   "The service account token resource allows you to create and delete a token for a Border0 service account. Tokens can be rotated automatically with `rotation_period` and `rotate_before_expiry`.",
+		SchemaVersion: 1,
 		ReadContext:   resourceServiceAccountTokenRead,
 		CreateContext: resourceServiceAccountTokenCreate,
+		UpdateContext: resourceServiceAccountTokenUpdate,
 		DeleteContext: resourceServiceAccountTokenDelete,
+		CustomizeDiff: customizeDiffTokenRotation,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceServiceAccountTokenImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"service_account_name": {
@@ -47,13 +57,48 @@ func resourceServiceAccountToken() *schema.Resource {
 			},
 		},
 	}
+	maps.Copy(tokenResource.Schema, tokenRotationAttributes())
+	tokenResource.StateUpgraders = []schema.StateUpgrader{
+		serviceAccountTokenIDCodec.LegacyIDStateUpgrader(0, tokenResource, 1, 0),
+	}
+	return tokenResource
+}
+
+// resourceServiceAccountTokenImport validates the id of an import. Ids used to have the token id first,
+// such ids are still accepted when the service account they name doesn't exist, but the other part does.
+func resourceServiceAccountTokenImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	parts, err := serviceAccountTokenIDCodec.Decode(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	serviceAccountName, serviceAccountTokenID := parts[0], parts[1]
+
+	client := m.(border0client.Requester)
+	_, err = client.ServiceAccount(ctx, serviceAccountName)
+	if err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+	if !border0client.NotFound(err) {
+		return nil, fmt.Errorf("failed to fetch service account %q: %w", serviceAccountName, err)
+	}
+
+	_, err = client.ServiceAccount(ctx, serviceAccountTokenID)
+	if err != nil && !border0client.NotFound(err) {
+		return nil, fmt.Errorf("failed to fetch service account %q: %w", serviceAccountTokenID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid id %q, service account %q not found, expected format is %s", d.Id(), serviceAccountName, serviceAccountTokenIDCodec.Format())
+	}
+	log.Printf("[WARN] Service account token id (%s) uses the legacy token_id:service_account_name format, expected format is %s", d.Id(), serviceAccountTokenIDCodec.Format())
+	serviceAccountTokenIDCodec.Set(d, serviceAccountTokenID, serviceAccountName)
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceServiceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := m.(border0client.Requester)
 
-	var serviceAccountTokenID, serviceAccountName string
-	diags := schemautil.LoadMultipartID(d, &serviceAccountTokenID, &serviceAccountName)
+	var serviceAccountName, serviceAccountTokenID string
+	diags := serviceAccountTokenIDCodec.Load(d, &serviceAccountName, &serviceAccountTokenID)
 	if diags.HasError() {
 		return diags
 	}
@@ -71,10 +116,15 @@ func resourceServiceAccountTokenRead(ctx context.Context, d *schema.ResourceData
 
 	for _, tk := range serviceAccountTokens.List {
 		if tk.ID == serviceAccountTokenID {
-			return schemautil.SetValues(d, map[string]any{
+			values := map[string]any{
 				"name":       tk.Name,
 				"expires_at": tk.ExpiresAt.String(),
-			})
+			}
+			// the creation time recorded by the create is kept when the api doesn't return it
+			if createdAt := tokenCreatedAt(tk.CreatedAt); createdAt != "" {
+				values["created_at"] = createdAt
+			}
+			return schemautil.SetValues(d, values)
 		}
 	}
 
@@ -108,10 +158,12 @@ func resourceServiceAccountTokenCreate(ctx context.Context, d *schema.ResourceDa
 		return diagnostics.Error(err, "Failed to create service account token")
 	}
 
-	schemautil.SetMultipartID(d, created.ID, serviceAccountName)
+	serviceAccountTokenIDCodec.Set(d, serviceAccountName, created.ID)
 	if diags := schemautil.SetValues(d, map[string]any{
 		"service_account_name": serviceAccountName,
 		"token":                created.Token,
+		"created_at":           time.Now().UTC().Format(time.RFC3339),
+		"ready_for_rotation":   false,
 	}); diags.HasError() {
 		return diags
 	}
@@ -120,11 +172,16 @@ func resourceServiceAccountTokenCreate(ctx context.Context, d *schema.ResourceDa
 	return resourceServiceAccountTokenRead(ctx, d, m)
 }
 
+func resourceServiceAccountTokenUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	// only the rotation settings can change in place, which just move the rotation window
+	return resourceServiceAccountTokenRead(ctx, d, m)
+}
+
 func resourceServiceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := m.(border0client.Requester)
 
-	var serviceAccountTokenID, serviceAccountName string
-	diags := schemautil.LoadMultipartID(d, &serviceAccountTokenID, &serviceAccountName)
+	var serviceAccountName, serviceAccountTokenID string
+	diags := serviceAccountTokenIDCodec.Load(d, &serviceAccountName, &serviceAccountTokenID)
 	if diags.HasError() {
 		return diags
 	}
@@ -1,10 +1,14 @@
 package border0_test
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
 	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/border0"
 	"github.com/borderzero/terraform-provider-border0/mocks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/stretchr/testify/require"
@@ -58,9 +62,19 @@ func Test_Resource_Border0ServiceAccountToken_NeverExpires(t *testing.T) {
 		clientMock.EXPECT().ServiceAccountTokens(matchContext, serviceAccount.Name).Return(&listOutput, nil).Call,
 		clientMock.EXPECT().ServiceAccountTokens(matchContext, serviceAccount.Name).Return(&listOutput, nil).Call,
 
-		// terraform import (read)
+		// terraform import (service account lookup + read)
+		clientMock.EXPECT().ServiceAccount(matchContext, serviceAccount.Name).Return(serviceAccount, nil).Call,
 		clientMock.EXPECT().ServiceAccountTokens(matchContext, serviceAccount.Name).Return(&listOutput, nil).Call,
 
+		// terraform import with a legacy token_id:service_account_name id (service account lookups + read)
+		clientMock.EXPECT().ServiceAccount(matchContext, createOutput.ID).Return(nil, border0client.Error{Code: http.StatusNotFound, Message: "service account not found"}).Call,
+		clientMock.EXPECT().ServiceAccount(matchContext, serviceAccount.Name).Return(serviceAccount, nil).Call,
+		clientMock.EXPECT().ServiceAccountTokens(matchContext, serviceAccount.Name).Return(&listOutput, nil).Call,
+
+		// terraform import of a service account that doesn't exist (service account lookups)
+		clientMock.EXPECT().ServiceAccount(matchContext, "no-such-service-account").Return(nil, border0client.Error{Code: http.StatusNotFound, Message: "service account not found"}).Call,
+		clientMock.EXPECT().ServiceAccount(matchContext, createOutput.ID).Return(nil, border0client.Error{Code: http.StatusNotFound, Message: "service account not found"}).Call,
+
 		// terraform destroy (delete)
 		clientMock.EXPECT().DeleteServiceAccountToken(matchContext, serviceAccount.Name, createOutput.ID).Return(nil).Call,
 	)
@@ -74,7 +88,8 @@ func Test_Resource_Border0ServiceAccountToken_NeverExpires(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("border0_service_account_token.unit_test_never_expires", "service_account_name", serviceAccount.Name),
 					resource.TestCheckResourceAttr("border0_service_account_token.unit_test_never_expires", "name", createOutput.Name),
-					resource.TestCheckResourceAttrSet("border0_service_account_token.unit_test_never_expires", "id"),
+					resource.TestCheckResourceAttr("border0_service_account_token.unit_test_never_expires", "id", serviceAccount.Name+":"+createOutput.ID),
+					resource.TestCheckResourceAttr("border0_service_account_token.unit_test_never_expires", "created_at", "2020-01-02T15:04:05Z"),
 				),
 			},
 			{
@@ -82,6 +97,26 @@ func Test_Resource_Border0ServiceAccountToken_NeverExpires(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: false, // skip verification because the token a computed value from the API
 			},
+			{
+				// a bare token id, without the service account name
+				ResourceName:  "border0_service_account_token.unit_test_never_expires",
+				ImportState:   true,
+				ImportStateId: createOutput.ID,
+				ExpectError:   regexp.MustCompile(`expected format is service_account_name:token_id`),
+			},
+			{
+				// the legacy order, with the token id first, is still accepted
+				ResourceName:      "border0_service_account_token.unit_test_never_expires",
+				ImportState:       true,
+				ImportStateId:     createOutput.ID + ":" + serviceAccount.Name,
+				ImportStateVerify: false, // skip verification because the token a computed value from the API
+			},
+			{
+				ResourceName:  "border0_service_account_token.unit_test_never_expires",
+				ImportState:   true,
+				ImportStateId: "no-such-service-account:" + createOutput.ID,
+				ExpectError:   regexp.MustCompile(`service account "no-such-service-account" not found, expected format is service_account_name:token_id`),
+			},
 		},
 	})
 }
@@ -142,7 +177,8 @@ func Test_Resource_Border0ServiceAccountToken_Expires(t *testing.T) {
 		clientMock.EXPECT().ServiceAccountTokens(matchContext, serviceAccount.Name).Return(&listOutput, nil).Call,
 		clientMock.EXPECT().ServiceAccountTokens(matchContext, serviceAccount.Name).Return(&listOutput, nil).Call,
 
-		// terraform import (read)
+		// terraform import (service account lookup + read)
+		clientMock.EXPECT().ServiceAccount(matchContext, serviceAccount.Name).Return(serviceAccount, nil).Call,
 		clientMock.EXPECT().ServiceAccountTokens(matchContext, serviceAccount.Name).Return(&listOutput, nil).Call,
 
 		// terraform destroy (delete)
@@ -160,6 +196,7 @@ func Test_Resource_Border0ServiceAccountToken_Expires(t *testing.T) {
 					resource.TestCheckResourceAttr("border0_service_account_token.unit_test_expires", "name", createOutput.Name),
 					resource.TestCheckResourceAttr("border0_service_account_token.unit_test_expires", "expires_at", createOutput.ExpiresAt.String()),
 					resource.TestCheckResourceAttrSet("border0_service_account_token.unit_test_expires", "id"),
+					resource.TestCheckResourceAttr("border0_service_account_token.unit_test_expires", "created_at", "2020-01-02T15:04:05Z"),
 				),
 			},
 			{
@@ -170,3 +207,21 @@ func Test_Resource_Border0ServiceAccountToken_Expires(t *testing.T) {
 		},
 	})
 }
+
+func Test_Resource_Border0ServiceAccountToken_StateUpgradeV0(t *testing.T) {
+	serviceAccountToken := border0.Provider().ResourcesMap["border0_service_account_token"]
+	require.Len(t, serviceAccountToken.StateUpgraders, 1)
+
+	// legacy ids had the token id first
+	upgraded, err := serviceAccountToken.StateUpgraders[0].Upgrade(context.Background(), map[string]any{
+		"id":                   "unit-test-sacc-token-id:unit-test-service-account",
+		"service_account_name": "unit-test-service-account",
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "unit-test-service-account:unit-test-sacc-token-id", upgraded["id"])
+
+	_, err = serviceAccountToken.StateUpgraders[0].Upgrade(context.Background(), map[string]any{
+		"id": "unit-test-sacc-token-id",
+	}, nil)
+	require.ErrorContains(t, err, `failed to migrate id "unit-test-sacc-token-id" to the format service_account_name:token_id`)
+}
@@ -3,12 +3,15 @@ package border0
 import (
 	"context"
 	"log"
+	"slices"
+	"sort"
 	"strings"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/border0-go/types/service"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
 	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil/schemaconvert"
 	"github.com/borderzero/terraform-provider-border0/internal/schemautil/socket/shared"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -92,6 +95,12 @@ func resourceSocket(semaphore *semaphore.Weighted) *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "The ID(s) of the connector(s) that the socket is attached to.",
 			},
+			"policy_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the policies attached to the socket. When set, this is authoritative: policies attached to the socket outside of this list (e.g. by hand in the portal) are detached, and an empty set detaches all policies. When unset, or removed from the configuration, the attached policies are left as they are. Org-wide policies are not affected. Do not combine with `border0_policy_attachment` resources for the same socket.",
+			},
 			"upstream_type": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -724,6 +733,18 @@ func resourceSocketRead(ctx context.Context, d *schema.ResourceData, m any) diag
 	if diags := schemautil.FromConnector(d, connectors); diags.HasError() {
 		return diags
 	}
+
+	// only manage policy attachments if policy_ids is set, even to an empty set
+	if socketPolicyIDsManaged(d) {
+		policyIDs, diags := fetchSocketPolicyIDs(ctx, client, d.Id())
+		if diags.HasError() {
+			return diags
+		}
+		if diags := schemautil.SetValues(d, map[string]any{"policy_ids": policyIDs}); diags.HasError() {
+			return diags
+		}
+	}
+
 	return schemautil.FromUpstreamConfig(d, socket, upstreamConfigs)
 }
 
@@ -746,6 +767,76 @@ func fetchSocket(ctx context.Context, d *schema.ResourceData, m any, idOrName st
 	return socket, nil
 }
 
+// socketPolicyIDsManaged returns whether the policies attached to the socket are managed, which is
+// when policy_ids is set, an empty set detaches all policies while an unset one leaves them as they
+// are. The configuration is only available when planning and applying, the state is checked otherwise.
+func socketPolicyIDsManaged(d *schema.ResourceData) bool {
+	if config := d.GetRawConfig(); !config.IsNull() {
+		return !config.GetAttr("policy_ids").IsNull()
+	}
+	state := d.GetRawState()
+	return !state.IsNull() && !state.GetAttr("policy_ids").IsNull()
+}
+
+// fetchSocketPolicyIDs returns the sorted ids of the (non org-wide) policies attached to a socket.
+func fetchSocketPolicyIDs(ctx context.Context, client border0client.Requester, socketID string) ([]string, diag.Diagnostics) {
+	policies, err := client.Policies(ctx)
+	if err != nil {
+		return nil, diagnostics.Error(err, "Failed to fetch policies")
+	}
+
+	policyIDs := []string{}
+	for _, policy := range policies {
+		if policy.OrgWide {
+			continue
+		}
+		if slices.Contains(policy.SocketIDs, socketID) {
+			policyIDs = append(policyIDs, policy.ID)
+		}
+	}
+	sort.Strings(policyIDs)
+	return policyIDs, nil
+}
+
+// reconcileSocketPolicies makes the set of policies attached to a socket match the desired
+// policy ids, attaching and detaching the difference against the current attachments in batch.
+func reconcileSocketPolicies(ctx context.Context, client border0client.Requester, socketID string, desired []string) diag.Diagnostics {
+	current, diags := fetchSocketPolicyIDs(ctx, client, socketID)
+	if diags.HasError() {
+		return diags
+	}
+
+	toAttach, toRemove := diffStringSlices(current, desired)
+	if len(toRemove) > 0 {
+		if err := client.RemovePoliciesFromSocket(ctx, toRemove, socketID); err != nil {
+			return diagnostics.Error(err, "Failed to remove policies from socket")
+		}
+	}
+	if len(toAttach) > 0 {
+		if err := client.AttachPoliciesToSocket(ctx, toAttach, socketID); err != nil {
+			return diagnostics.Error(err, "Failed to attach policies to socket")
+		}
+	}
+	return nil
+}
+
+// diffStringSlices returns the (sorted) elements that need to be added to and removed from current to get to desired.
+func diffStringSlices(current, desired []string) (toAdd, toRemove []string) {
+	for _, s := range desired {
+		if !slices.Contains(current, s) && !slices.Contains(toAdd, s) {
+			toAdd = append(toAdd, s)
+		}
+	}
+	for _, s := range current {
+		if !slices.Contains(desired, s) && !slices.Contains(toRemove, s) {
+			toRemove = append(toRemove, s)
+		}
+	}
+	sort.Strings(toAdd)
+	sort.Strings(toRemove)
+	return toAdd, toRemove
+}
+
 func getResourceSocketCreate(sem *semaphore.Weighted) schema.CreateContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 		if err := sem.Acquire(ctx, 1); err != nil {
@@ -774,6 +865,15 @@ func getResourceSocketCreate(sem *semaphore.Weighted) schema.CreateContextFunc {
 
 		d.SetId(created.SocketID)
 
+		if v, ok := d.GetOk("policy_ids"); ok {
+			helper.ReadAfterWriteDelay()
+			policyIDs := schemaconvert.SetToSlice[string](v.(*schema.Set))
+			sort.Strings(policyIDs)
+			if err := client.AttachPoliciesToSocket(ctx, policyIDs, created.SocketID); err != nil {
+				return diagnostics.Error(err, "Failed to attach policies to socket")
+			}
+		}
+
 		helper.ReadAfterWriteDelay()
 		return resourceSocketRead(ctx, d, m)
 	}
@@ -789,7 +889,7 @@ func getResourceSocketUpdate(sem *semaphore.Weighted) schema.UpdateContextFunc {
 		helper := m.(*ProviderHelper)
 		client := helper.Requester
 
-		if d.HasChangesExcept("socket_type") {
+		if d.HasChangesExcept("socket_type", "policy_ids") {
 			existingSocket, err := client.Socket(ctx, d.Id())
 			if err != nil {
 				return diagnostics.Error(err, "Failed to fetch socket")
@@ -813,6 +913,15 @@ func getResourceSocketUpdate(sem *semaphore.Weighted) schema.UpdateContextFunc {
 			}
 		}
 
+		// removing policy_ids stops managing the attachments, and an empty set is not a change from an
+		// unset one, but still starts managing them
+		if socketPolicyIDsManaged(d) && (d.HasChange("policy_ids") || d.GetRawState().GetAttr("policy_ids").IsNull()) {
+			desired := schemaconvert.SetToSlice[string](d.Get("policy_ids").(*schema.Set))
+			if diags := reconcileSocketPolicies(ctx, client, d.Id(), desired); diags.HasError() {
+				return diags
+			}
+		}
+
 		helper.ReadAfterWriteDelay()
 		return resourceSocketRead(ctx, d, m)
 	}
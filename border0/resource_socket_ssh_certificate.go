@@ -0,0 +1,209 @@
+package border0
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
+)
+
+const defaultSSHCertificateRenewBefore = "1h"
+
+func resourceSocketSSHCertificate() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The socket SSH certificate resource signs an OpenSSH public key for a Border0 socket. The signed certificate is re-issued when it is within `renew_before` of its expiry.",
+		ReadContext:   resourceSocketSSHCertificateRead,
+		CreateContext: resourceSocketSSHCertificateCreate,
+		UpdateContext: resourceSocketSSHCertificateUpdate,
+		DeleteContext: resourceSocketSSHCertificateDelete,
+		CustomizeDiff: resourceSocketSSHCertificateCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"socket_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID (or name) of the socket to sign the public key for.",
+			},
+			"public_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSSHPublicKey,
+				Description:  "The OpenSSH public key to sign, in `authorized_keys` format e.g. `ssh-ed25519 AAAA... user@host`.",
+			},
+			"renew_before": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultSSHCertificateRenewBefore,
+				ValidateFunc: validateDuration,
+				Description:  "How long before the certificate expires it should be re-signed, as a duration e.g. `30m` or `2h`. Defaults to `1h`.",
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The signed OpenSSH certificate, in `authorized_keys` format.",
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key ID embedded in the signed certificate.",
+			},
+			"principals": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The principals the signed certificate is valid for.",
+			},
+			"valid_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time (RFC 3339) from which the signed certificate is valid.",
+			},
+			"valid_before": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time (RFC 3339) at which the signed certificate expires.",
+			},
+			"ready_for_renewal": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the certificate is within `renew_before` of its expiry. It's only `true` in plans, where it forces the certificate to be re-signed.",
+			},
+		},
+	}
+}
+
+func resourceSocketSSHCertificateRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	// the certificate is a self-contained artifact, so there is nothing to fetch from the api. The
+	// renewal is only evaluated when planning, the state is always false so that setting it to
+	// true in the plan is a change that can force the replacement of the certificate
+	return schemautil.SetValues(d, map[string]any{
+		"ready_for_renewal": false,
+	})
+}
+
+func resourceSocketSSHCertificateCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	socketID := d.Get("socket_id").(string)
+	publicKey := strings.TrimSpace(d.Get("public_key").(string))
+
+	signed, err := client.SignSocketKey(ctx, socketID, &border0client.SocketKeyToSign{SSHPublicKey: publicKey})
+	if err != nil {
+		return diagnostics.Error(err, "Failed to sign public key for socket")
+	}
+
+	cert, err := parseSSHCertificate(signed.SignedSSHCert)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to parse signed certificate")
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d", socketID, cert.Serial))
+
+	if diags := schemautil.SetValues(d, map[string]any{
+		"certificate":  strings.TrimSpace(signed.SignedSSHCert),
+		"key_id":       cert.KeyId,
+		"principals":   cert.ValidPrincipals,
+		"valid_after":  sshCertificateTime(cert.ValidAfter),
+		"valid_before": sshCertificateTime(cert.ValidBefore),
+	}); diags.HasError() {
+		return diags
+	}
+
+	return resourceSocketSSHCertificateRead(ctx, d, m)
+}
+
+func resourceSocketSSHCertificateUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	// only renew_before can change in place, which just moves the renewal window
+	return resourceSocketSSHCertificateRead(ctx, d, m)
+}
+
+func resourceSocketSSHCertificateDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	// signed certificates can not be revoked, they simply expire, so
+	// deleting the resource only removes it from the terraform state
+	d.SetId("")
+	return nil
+}
+
+func resourceSocketSSHCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	readyForRenewal, err := sshCertificateReadyForRenewal(d.Get("valid_before").(string), d.Get("renew_before").(string), time.Now())
+	if err != nil {
+		return err
+	}
+	if !readyForRenewal {
+		return nil
+	}
+	// the state is always false, so setting it to true is a change that can force the replacement
+	if err := d.SetNew("ready_for_renewal", true); err != nil {
+		return err
+	}
+	return d.ForceNew("ready_for_renewal")
+}
+
+func parseSSHCertificate(signed string) (*ssh.Certificate, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(signed))
+	if err != nil {
+		return nil, err
+	}
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("signed key is a %s public key, not an ssh certificate", key.Type())
+	}
+	return cert, nil
+}
+
+// sshCertificateTime converts an ssh certificate validity bound to RFC 3339,
+// the special "forever" value is returned as an empty string.
+func sshCertificateTime(t uint64) string {
+	if t == ssh.CertTimeInfinity {
+		return ""
+	}
+	return time.Unix(int64(t), 0).UTC().Format(time.RFC3339)
+}
+
+func sshCertificateReadyForRenewal(validBefore, renewBefore string, now time.Time) (bool, error) {
+	if validBefore == "" {
+		return false, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, validBefore)
+	if err != nil {
+		return false, fmt.Errorf("invalid valid_before %q: %w", validBefore, err)
+	}
+	if renewBefore == "" {
+		renewBefore = defaultSSHCertificateRenewBefore
+	}
+	window, err := time.ParseDuration(renewBefore)
+	if err != nil {
+		return false, fmt.Errorf("invalid renew_before %q: %w", renewBefore, err)
+	}
+	return !now.Before(expiresAt.Add(-window)), nil
+}
+
+func validateSSHPublicKey(v any, k string) ([]string, []error) {
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(v.(string))); err != nil {
+		return nil, []error{fmt.Errorf("%q is not a valid OpenSSH public key: %v", k, err)}
+	}
+	return nil, nil
+}
+
+func validateDuration(v any, k string) ([]string, []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q is not a valid duration e.g. 10m: %v", k, err)}
+	}
+	if duration < 0 {
+		return nil, []error{fmt.Errorf("%q must not be negative", k)}
+	}
+	return nil, nil
+}
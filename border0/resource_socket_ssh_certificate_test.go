@@ -0,0 +1,149 @@
+package border0_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func Test_Resource_Border0SocketSSHCertificate(t *testing.T) {
+	userPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshUserPublicKey, err := ssh.NewPublicKey(userPublicKey)
+	require.NoError(t, err)
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshUserPublicKey)))
+
+	_, caPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	caSigner, err := ssh.NewSignerFromKey(caPrivateKey)
+	require.NoError(t, err)
+
+	validAfter := time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+	validBefore := validAfter.Add(24 * time.Hour)
+	cert := &ssh.Certificate{
+		Key:             sshUserPublicKey,
+		Serial:          42,
+		CertType:        ssh.UserCert,
+		KeyId:           "break-glass@example.com",
+		ValidPrincipals: []string{"break-glass@example.com"},
+		ValidAfter:      uint64(validAfter.Unix()),
+		ValidBefore:     uint64(validBefore.Unix()),
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, caSigner))
+	signedCert := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(cert)))
+
+	config := fmt.Sprintf(`
+		resource "border0_socket_ssh_certificate" "unit_test" {
+			socket_id  = "unit-test-socket-id"
+			public_key = "%s"
+		}`,
+		authorizedKey,
+	)
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create), subsequent reads are local only
+		clientMock.EXPECT().SignSocketKey(matchContext, "unit-test-socket-id", &border0client.SocketKeyToSign{SSHPublicKey: authorizedKey}).Return(&border0client.SignedSocketKey{SignedSSHCert: signedCert}, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "certificate", signedCert),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "key_id", "break-glass@example.com"),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "principals.#", "1"),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "principals.0", "break-glass@example.com"),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "valid_after", validAfter.Format(time.RFC3339)),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "valid_before", validBefore.Format(time.RFC3339)),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "renew_before", "1h"),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "ready_for_renewal", "false"),
+					resource.TestCheckResourceAttrSet("border0_socket_ssh_certificate.unit_test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0SocketSSHCertificate_Renewal(t *testing.T) {
+	userPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshUserPublicKey, err := ssh.NewPublicKey(userPublicKey)
+	require.NoError(t, err)
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshUserPublicKey)))
+
+	_, caPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	caSigner, err := ssh.NewSignerFromKey(caPrivateKey)
+	require.NoError(t, err)
+
+	signCert := func(serial uint64, validBefore time.Time) string {
+		cert := &ssh.Certificate{
+			Key:             sshUserPublicKey,
+			Serial:          serial,
+			CertType:        ssh.UserCert,
+			KeyId:           "break-glass@example.com",
+			ValidPrincipals: []string{"break-glass@example.com"},
+			ValidAfter:      uint64(time.Now().Add(-time.Minute).Unix()),
+			ValidBefore:     uint64(validBefore.Unix()),
+		}
+		require.NoError(t, cert.SignCert(rand.Reader, caSigner))
+		return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(cert)))
+	}
+	// the first certificate expires within the default renew_before of 1h, so it's due straight away
+	dueCert := signCert(1, time.Now().Add(30*time.Minute))
+	renewedCert := signCert(2, time.Now().Add(24*time.Hour))
+
+	config := fmt.Sprintf(`
+		resource "border0_socket_ssh_certificate" "unit_test" {
+			socket_id  = "unit-test-socket-id"
+			public_key = "%s"
+		}`,
+		authorizedKey,
+	)
+
+	keyToSign := &border0client.SocketKeyToSign{SSHPublicKey: authorizedKey}
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (create)
+		clientMock.EXPECT().SignSocketKey(matchContext, "unit-test-socket-id", keyToSign).Return(&border0client.SignedSocketKey{SignedSSHCert: dueCert}, nil).Call,
+
+		// terraform apply (renewal), planned from the refreshed state of the due certificate
+		clientMock.EXPECT().SignSocketKey(matchContext, "unit-test-socket-id", keyToSign).Return(&border0client.SignedSocketKey{SignedSSHCert: renewedCert}, nil).Call,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "id", "unit-test-socket-id-1"),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "ready_for_renewal", "false"),
+				),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "id", "unit-test-socket-id-2"),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "certificate", renewedCert),
+					resource.TestCheckResourceAttr("border0_socket_ssh_certificate.unit_test", "ready_for_renewal", "false"),
+				),
+			},
+		},
+	})
+}
@@ -1,12 +1,14 @@
 package border0_test
 
 import (
+	"fmt"
 	"testing"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/border0-go/client/enum"
 	"github.com/borderzero/terraform-provider-border0/mocks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
 )
 
 var httpSocketConfig = `
@@ -154,3 +156,229 @@ func Test_Resource_Border0Socket_HTTPBasic(t *testing.T) {
 		},
 	})
 }
+
+func Test_Resource_Border0Socket_PolicyIDs(t *testing.T) {
+	socketID := "unit-test-http-socket-id"
+
+	input := border0client.Socket{
+		Name:         "unit-test-http-socket",
+		SocketType:   enum.SocketTypeHTTP,
+		UpstreamType: "http",
+	}
+	output := border0client.Socket{
+		SocketID:     socketID,
+		Name:         "unit-test-http-socket",
+		SocketType:   enum.SocketTypeHTTP,
+		UpstreamType: "http",
+	}
+
+	orgWidePolicy := border0client.Policy{ID: "policy-org-wide", OrgWide: true, SocketIDs: []string{socketID}}
+	initialPolicies := []border0client.Policy{
+		{ID: "policy-a", SocketIDs: []string{socketID}},
+		{ID: "policy-b", SocketIDs: []string{socketID}},
+		{ID: "policy-c", SocketIDs: []string{"another-socket-id"}},
+		orgWidePolicy,
+	}
+	// policy-manual was attached by hand outside of terraform
+	driftedPolicies := []border0client.Policy{
+		{ID: "policy-a", SocketIDs: []string{socketID}},
+		{ID: "policy-b", SocketIDs: []string{socketID}},
+		{ID: "policy-c", SocketIDs: []string{"another-socket-id"}},
+		{ID: "policy-manual", SocketIDs: []string{socketID}},
+		orgWidePolicy,
+	}
+	updatedPolicies := []border0client.Policy{
+		{ID: "policy-a"},
+		{ID: "policy-b", SocketIDs: []string{socketID}},
+		{ID: "policy-c", SocketIDs: []string{"another-socket-id", socketID}},
+		{ID: "policy-manual"},
+		orgWidePolicy,
+	}
+
+	config := `
+resource "border0_socket" "unit_test_http" {
+  name        = "unit-test-http-socket"
+  socket_type = "http"
+  policy_ids  = [ "policy-b", "policy-a" ]
+}
+`
+	configUpdate := `
+resource "border0_socket" "unit_test_http" {
+  name        = "unit-test-http-socket"
+  socket_type = "http"
+  policy_ids  = [ "policy-b", "policy-c" ]
+}
+`
+
+	clientMock := mocks.APIClientRequester{}
+	readCalls := func(policies []border0client.Policy) []*mock.Call {
+		return []*mock.Call{
+			clientMock.EXPECT().Socket(matchContext, socketID).Return(&output, nil).Call,
+			clientMock.EXPECT().SocketConnectors(matchContext, socketID).Return(new(border0client.SocketConnectors), nil).Call,
+			clientMock.EXPECT().SocketUpstreamConfigs(matchContext, socketID).Return(new(border0client.SocketUpstreamConfigs), nil).Call,
+			clientMock.EXPECT().Policies(matchContext).Return(policies, nil).Call,
+		}
+	}
+
+	calls := []*mock.Call{
+		// terraform apply (create + attach + read + read)
+		clientMock.EXPECT().CreateSocket(matchContext, &input).Return(&output, nil).Call,
+		clientMock.EXPECT().AttachPoliciesToSocket(matchContext, []string{"policy-a", "policy-b"}, socketID).Return(nil).Call,
+	}
+	calls = append(calls, readCalls(initialPolicies)...)
+	calls = append(calls, readCalls(initialPolicies)...)
+
+	// this read is needed because of the update, and picks up the drift
+	calls = append(calls, readCalls(driftedPolicies)...)
+
+	// terraform apply (reconcile + read + read)
+	calls = append(calls,
+		clientMock.EXPECT().Policies(matchContext).Return(driftedPolicies, nil).Call,
+		clientMock.EXPECT().RemovePoliciesFromSocket(matchContext, []string{"policy-a", "policy-manual"}, socketID).Return(nil).Call,
+		clientMock.EXPECT().AttachPoliciesToSocket(matchContext, []string{"policy-c"}, socketID).Return(nil).Call,
+	)
+	calls = append(calls, readCalls(updatedPolicies)...)
+	calls = append(calls, readCalls(updatedPolicies)...)
+
+	// terraform destroy (delete)
+	calls = append(calls, clientMock.EXPECT().DeleteSocket(matchContext, socketID).Return(nil).Call)
+
+	mockCallsInOrder(calls...)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_socket.unit_test_http", "policy_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("border0_socket.unit_test_http", "policy_ids.*", "policy-a"),
+					resource.TestCheckTypeSetElemAttr("border0_socket.unit_test_http", "policy_ids.*", "policy-b"),
+				),
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_socket.unit_test_http", "policy_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("border0_socket.unit_test_http", "policy_ids.*", "policy-b"),
+					resource.TestCheckTypeSetElemAttr("border0_socket.unit_test_http", "policy_ids.*", "policy-c"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0Socket_PolicyIDsEmptyAndRemoved(t *testing.T) {
+	socketID := "unit-test-http-socket-id"
+
+	input := border0client.Socket{
+		Name:         "unit-test-http-socket",
+		SocketType:   enum.SocketTypeHTTP,
+		UpstreamType: "http",
+	}
+	output := border0client.Socket{
+		SocketID:     socketID,
+		Name:         "unit-test-http-socket",
+		SocketType:   enum.SocketTypeHTTP,
+		UpstreamType: "http",
+	}
+
+	attachedPolicies := []border0client.Policy{
+		{ID: "policy-a", SocketIDs: []string{socketID}},
+		{ID: "policy-manual"},
+	}
+	// policy-manual was attached by hand outside of terraform
+	driftedPolicies := []border0client.Policy{
+		{ID: "policy-a", SocketIDs: []string{socketID}},
+		{ID: "policy-manual", SocketIDs: []string{socketID}},
+	}
+	detachedPolicies := []border0client.Policy{
+		{ID: "policy-a"},
+		{ID: "policy-manual"},
+	}
+	reattachedPolicies := []border0client.Policy{
+		{ID: "policy-a"},
+		{ID: "policy-manual", SocketIDs: []string{socketID}},
+	}
+
+	config := func(policyIDs string) string {
+		return fmt.Sprintf(`
+resource "border0_socket" "unit_test_http" {
+  name        = "unit-test-http-socket"
+  socket_type = "http"
+  %s
+}
+`, policyIDs)
+	}
+
+	clientMock := mocks.APIClientRequester{}
+	readCalls := func(policies []border0client.Policy) []*mock.Call {
+		calls := []*mock.Call{
+			clientMock.EXPECT().Socket(matchContext, socketID).Return(&output, nil).Call,
+			clientMock.EXPECT().SocketConnectors(matchContext, socketID).Return(new(border0client.SocketConnectors), nil).Call,
+			clientMock.EXPECT().SocketUpstreamConfigs(matchContext, socketID).Return(new(border0client.SocketUpstreamConfigs), nil).Call,
+		}
+		if policies != nil {
+			calls = append(calls, clientMock.EXPECT().Policies(matchContext).Return(policies, nil).Call)
+		}
+		return calls
+	}
+
+	calls := []*mock.Call{
+		// terraform apply (create + attach + read + read)
+		clientMock.EXPECT().CreateSocket(matchContext, &input).Return(&output, nil).Call,
+		clientMock.EXPECT().AttachPoliciesToSocket(matchContext, []string{"policy-a"}, socketID).Return(nil).Call,
+	}
+	calls = append(calls, readCalls(attachedPolicies)...)
+	calls = append(calls, readCalls(attachedPolicies)...)
+
+	// this read is needed because of the update, and picks up the drift
+	calls = append(calls, readCalls(driftedPolicies)...)
+
+	// terraform apply (reconcile + read + read), an empty policy_ids detaches all policies
+	calls = append(calls,
+		clientMock.EXPECT().Policies(matchContext).Return(driftedPolicies, nil).Call,
+		clientMock.EXPECT().RemovePoliciesFromSocket(matchContext, []string{"policy-a", "policy-manual"}, socketID).Return(nil).Call,
+	)
+	calls = append(calls, readCalls(detachedPolicies)...)
+	calls = append(calls, readCalls(detachedPolicies)...)
+
+	// this read is needed because of the update, the empty policy_ids is still managed and picks up the drift
+	calls = append(calls, readCalls(reattachedPolicies)...)
+
+	// terraform apply (read + read), removing policy_ids leaves the attached policies as they are
+	calls = append(calls, readCalls(nil)...)
+	calls = append(calls, readCalls(nil)...)
+
+	// terraform destroy (delete)
+	calls = append(calls, clientMock.EXPECT().DeleteSocket(matchContext, socketID).Return(nil).Call)
+
+	mockCallsInOrder(calls...)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`policy_ids  = [ "policy-a" ]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_socket.unit_test_http", "policy_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("border0_socket.unit_test_http", "policy_ids.*", "policy-a"),
+				),
+			},
+			{
+				Config: config(`policy_ids  = []`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_socket.unit_test_http", "policy_ids.#", "0"),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("border0_socket.unit_test_http", "policy_ids.#"),
+				),
+			},
+		},
+	})
+}
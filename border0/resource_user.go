@@ -2,7 +2,9 @@ package border0
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	border0client "github.com/borderzero/border0-go/client"
 	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
@@ -18,8 +20,9 @@ func resourceUser() *schema.Resource {
 		CreateContext: resourceUserCreate,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		CustomizeDiff: customizeDiffRole,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceUserImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"display_name": {
@@ -35,7 +38,7 @@ func resourceUser() *schema.Resource {
 			"role": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The role for the user. Currently valid values include 'admin', 'member', 'read only', and 'client'.",
+				Description: "The role for the user. Currently valid values include 'admin', 'member', 'read only', and 'client', see the `border0_roles` data source for the roles of your organization.",
 			},
 			"notify_by_email": {
 				Type:        schema.TypeBool,
@@ -139,3 +142,38 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m any) diag
 	d.SetId("")
 	return nil
 }
+
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	client := m.(border0client.Requester)
+
+	// users can be imported by email as well as by id, emails are resolved to the user id
+	if email := d.Id(); strings.Contains(email, "@") {
+		// NOTE: internally uses the border0 go sdk's users paginator
+		// to retrieve all pages of users in the organization, using
+		// the default page size defined there.
+		users, err := client.Users(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch users list: %w", err)
+		}
+
+		var found *border0client.User
+		for i := range users.List {
+			if strings.EqualFold(users.List[i].Email, email) {
+				found = &users.List[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("user with email %q not found", email)
+		}
+		d.SetId(found.ID)
+	}
+
+	// the user already exists, so there is nothing to notify them about, use
+	// the default value to avoid a diff when notify_by_email is not configured
+	if err := d.Set("notify_by_email", true); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
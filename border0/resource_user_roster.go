@@ -0,0 +1,387 @@
+package border0
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/internal/diagnostics"
+	"github.com/borderzero/terraform-provider-border0/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/sync/errgroup"
+)
+
+// Results of reconciling a user of a border0_user_roster.
+const (
+	rosterResultCreated   = "created"
+	rosterResultUpdated   = "updated"
+	rosterResultUnchanged = "unchanged"
+	rosterResultDeleted   = "deleted"
+	rosterResultReleased  = "released"
+	rosterResultFailed    = "failed"
+)
+
+func resourceUserRoster() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The user roster resource allows you to manage many Border0 users at once, e.g. from an HR export. Users are matched by email, missing users are created, users whose display name or role differ are updated, and existing users that are not managed yet are adopted.",
+		ReadContext:   resourceUserRosterRead,
+		CreateContext: resourceUserRosterCreate,
+		UpdateContext: resourceUserRosterUpdate,
+		DeleteContext: resourceUserRosterDelete,
+		CustomizeDiff: resourceUserRosterCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The users in the roster. The order of the users doesn't matter.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The email address of the user, matched case-insensitively. Each email can only be listed once.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The display name for the user.",
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The role for the user. Currently valid values include 'admin', 'member', 'read only', and 'client', see the `border0_roles` data source for the roles of your organization.",
+						},
+					},
+				},
+			},
+			"prune": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the users that are removed from the roster, or that are in the roster when it is destroyed. When `false`, they are only no longer managed by the roster. Users that fail to be deleted are kept in the roster, so they are deleted again on the next apply. The default value is `false`.",
+			},
+			"notify_by_email": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to notify the users that are created that they have been added via email. Defaults to true",
+			},
+			"max_concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 20),
+				Description:  "The maximum number of users that are created, updated or deleted at the same time. The default value is `5`.",
+			},
+			"user_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the users in the roster, keyed by email.",
+			},
+			"results": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "What the last apply did to each user, keyed by email. One of `created`, `updated`, `unchanged`, `deleted` (removed from the roster with `prune`), `released` (removed from the roster without `prune`) or `failed` (retried on the next apply, reported as an error when deleting the user with `prune` failed and as a warning otherwise).",
+			},
+		},
+	}
+}
+
+// rosterUser is a user of a border0_user_roster, as configured.
+type rosterUser struct {
+	Email       string
+	DisplayName string
+	Role        string
+}
+
+func resourceUserRosterRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(border0client.Requester)
+
+	// NOTE: internally uses the border0 go sdk's users paginator
+	// to retrieve all pages of users in the organization, using
+	// the default page size defined there.
+	users, err := client.Users(ctx)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch users list")
+	}
+	orgUsers := usersByEmail(users.List)
+
+	// users that were deleted outside of terraform are dropped from the roster, so they are created again
+	var rosterUsers []any
+	userIDs := map[string]any{}
+	for _, user := range expandRosterUsers(d.Get("user")) {
+		orgUser, ok := orgUsers[strings.ToLower(user.Email)]
+		if !ok {
+			log.Printf("[WARN] User (%s) of user roster (%s) not found, removing from state", user.Email, d.Id())
+			continue
+		}
+		rosterUsers = append(rosterUsers, map[string]any{
+			"email":        user.Email,
+			"display_name": orgUser.DisplayName,
+			"role":         orgUser.Role,
+		})
+		userIDs[user.Email] = orgUser.ID
+	}
+
+	return schemautil.SetValues(d, map[string]any{
+		"user":     rosterUsers,
+		"user_ids": userIDs,
+	})
+}
+
+func resourceUserRosterCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	d.SetId(id.UniqueId())
+	return reconcileUserRoster(ctx, d, m, nil)
+}
+
+func resourceUserRosterUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	old, _ := d.GetChange("user")
+	return reconcileUserRoster(ctx, d, m, expandRosterUsers(old))
+}
+
+func resourceUserRosterDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if d.Get("prune").(bool) {
+		userIDs := d.Get("user_ids").(map[string]any)
+		results := map[string]string{}
+		diags := runRosterOperations(d.Get("max_concurrency").(int), expandRosterUsers(d.Get("user")), results, func(user rosterUser) (string, error) {
+			return deleteRosterUser(ctx, m.(border0client.Requester), user, userIDs)
+		})
+		// the users that failed to be deleted would be left behind without anything managing them
+		if len(diags) > 0 {
+			for i := range diags {
+				diags[i].Severity = diag.Error
+			}
+			return diags
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceUserRosterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if !d.HasChange("user") || !d.NewValueKnown("user") {
+		return nil
+	}
+
+	roles, err := assignableRoles(ctx, m)
+	if err != nil {
+		return fmt.Errorf("failed to fetch roles list: %w", err)
+	}
+	seen := map[string]bool{}
+	for _, user := range expandRosterUsers(d.Get("user")) {
+		email := strings.ToLower(user.Email)
+		if seen[email] {
+			return fmt.Errorf("email %q is listed more than once in the user roster", user.Email)
+		}
+		seen[email] = true
+
+		if err := validateRole(roles, user.Role); err != nil {
+			return fmt.Errorf("invalid user %s: %w", user.Email, err)
+		}
+	}
+
+	if err := d.SetNewComputed("user_ids"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("results")
+}
+
+// reconcileUserRoster creates, updates and deletes users so the organization matches the roster.
+// The previous users are the users of the roster before the change, nil when it is created.
+func reconcileUserRoster(ctx context.Context, d *schema.ResourceData, m any, previous []rosterUser) diag.Diagnostics {
+	helper := m.(*ProviderHelper)
+	client := helper.Requester
+
+	// NOTE: internally uses the border0 go sdk's users paginator
+	// to retrieve all pages of users in the organization, using
+	// the default page size defined there.
+	users, err := client.Users(ctx)
+	if err != nil {
+		return diagnostics.Error(err, "Failed to fetch users list")
+	}
+	orgUsers := usersByEmail(users.List)
+
+	desired := expandRosterUsers(d.Get("user"))
+	inRoster := map[string]bool{}
+	for _, user := range desired {
+		inRoster[strings.ToLower(user.Email)] = true
+	}
+	var removed []rosterUser
+	for _, user := range previous {
+		if !inRoster[strings.ToLower(user.Email)] {
+			removed = append(removed, user)
+		}
+	}
+
+	var opts []border0client.UserOption
+	if !d.Get("notify_by_email").(bool) {
+		opts = append(opts, border0client.WithSkipNotification(true))
+	}
+	prune := d.Get("prune").(bool)
+	// the ids in the plan are unknown while the roster changes, the removed users are in the previous ids
+	oldUserIDs, _ := d.GetChange("user_ids")
+	userIDs, _ := oldUserIDs.(map[string]any)
+	maxConcurrency := d.Get("max_concurrency").(int)
+
+	results := map[string]string{}
+	diags := runRosterOperations(maxConcurrency, desired, results, func(user rosterUser) (string, error) {
+		orgUser, ok := orgUsers[strings.ToLower(user.Email)]
+		if !ok {
+			if _, err := client.CreateUser(ctx, &border0client.User{
+				Email:       user.Email,
+				DisplayName: user.DisplayName,
+				Role:        user.Role,
+			}, opts...); err != nil {
+				return "", err
+			}
+			return rosterResultCreated, nil
+		}
+		if orgUser.DisplayName == user.DisplayName && orgUser.Role == user.Role {
+			return rosterResultUnchanged, nil
+		}
+		if _, err := client.UpdateUser(ctx, &border0client.User{
+			ID:          orgUser.ID,
+			Email:       orgUser.Email,
+			DisplayName: user.DisplayName,
+			Role:        user.Role,
+		}); err != nil {
+			return "", err
+		}
+		return rosterResultUpdated, nil
+	})
+	// the users that failed to be pruned would be left behind without anything managing them, so
+	// their failures are errors, like when the roster is deleted
+	pruneDiags := runRosterOperations(maxConcurrency, removed, results, func(user rosterUser) (string, error) {
+		if !prune {
+			return rosterResultReleased, nil
+		}
+		return deleteRosterUser(ctx, client, user, userIDs)
+	})
+	for i := range pruneDiags {
+		pruneDiags[i].Severity = diag.Error
+	}
+	diags = append(diags, pruneDiags...)
+
+	helper.ReadAfterWriteDelay()
+	diags = append(diags, resourceUserRosterRead(ctx, d, m)...)
+	if len(pruneDiags) > 0 {
+		diags = append(diags, keepFailedRemovals(d, removed, results, userIDs)...)
+	}
+	if err := d.Set("results", results); err != nil {
+		diags = append(diags, diagnostics.Error(err, "Failed to set results")...)
+	}
+	return diags
+}
+
+// keepFailedRemovals adds the removed users that failed to be pruned back to the roster's state, so
+// the next plan removes them again and their deletion is retried.
+func keepFailedRemovals(d *schema.ResourceData, removed []rosterUser, results map[string]string, previousUserIDs map[string]any) diag.Diagnostics {
+	rosterUsers := d.Get("user").(*schema.Set).List()
+	userIDs := d.Get("user_ids").(map[string]any)
+	for _, user := range removed {
+		if results[user.Email] != rosterResultFailed {
+			continue
+		}
+		rosterUsers = append(rosterUsers, map[string]any{
+			"email":        user.Email,
+			"display_name": user.DisplayName,
+			"role":         user.Role,
+		})
+		if userID, ok := previousUserIDs[user.Email]; ok {
+			userIDs[user.Email] = userID
+		}
+	}
+	return schemautil.SetValues(d, map[string]any{
+		"user":     rosterUsers,
+		"user_ids": userIDs,
+	})
+}
+
+// runRosterOperations runs the operation for each user, at most maxConcurrency at the same time. The
+// result of each operation is recorded by email. A failed operation doesn't stop the other ones, and is
+// reported as a warning rather than an error, so a failed user doesn't taint the whole roster. The users
+// are reconciled again on the next apply.
+func runRosterOperations(maxConcurrency int, users []rosterUser, results map[string]string, operation func(rosterUser) (string, error)) diag.Diagnostics {
+	var lock sync.Mutex
+	errs := map[string]error{}
+
+	var group errgroup.Group
+	group.SetLimit(maxConcurrency)
+	for _, user := range users {
+		group.Go(func() error {
+			result, err := operation(user)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				results[user.Email] = rosterResultFailed
+				errs[user.Email] = err
+				return nil
+			}
+			results[user.Email] = result
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	// the operations finish in any order, report the failures in the order of the users
+	var diags diag.Diagnostics
+	for _, user := range users {
+		if err, ok := errs[user.Email]; ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to reconcile user %s", user.Email),
+				Detail:   err.Error(),
+			})
+		}
+	}
+	return diags
+}
+
+func deleteRosterUser(ctx context.Context, client border0client.Requester, user rosterUser, userIDs map[string]any) (string, error) {
+	userID, ok := userIDs[user.Email].(string)
+	if !ok {
+		// never created, e.g. because its creation failed
+		return rosterResultDeleted, nil
+	}
+	// nothing left to delete if the user was already deleted
+	if err := client.DeleteUser(ctx, userID); err != nil && !border0client.NotFound(err) {
+		return "", err
+	}
+	return rosterResultDeleted, nil
+}
+
+func expandRosterUsers(v any) []rosterUser {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+	users := make([]rosterUser, 0, set.Len())
+	for _, raw := range set.List() {
+		user := raw.(map[string]any)
+		users = append(users, rosterUser{
+			Email:       user["email"].(string),
+			DisplayName: user["display_name"].(string),
+			Role:        user["role"].(string),
+		})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+	return users
+}
+
+func usersByEmail(users []border0client.User) map[string]border0client.User {
+	byEmail := make(map[string]border0client.User, len(users))
+	for _, user := range users {
+		byEmail[strings.ToLower(user.Email)] = user
+	}
+	return byEmail
+}
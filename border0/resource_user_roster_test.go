@@ -0,0 +1,269 @@
+package border0_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/borderzero/terraform-provider-border0/mocks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Resource_Border0UserRoster(t *testing.T) {
+	alice := border0client.User{ID: "unit-test-user-id-1", Email: "alice@unit-test.com", DisplayName: "Alice", Role: "member"}
+	bob := border0client.User{ID: "unit-test-user-id-2", Email: "bob@unit-test.com", DisplayName: "Bob", Role: "member"}
+	carol := border0client.User{ID: "unit-test-user-id-3", Email: "carol@unit-test.com", DisplayName: "Carol", Role: "member"}
+	outsider := border0client.User{ID: "unit-test-user-id-4", Email: "outsider@unit-test.com", DisplayName: "Outsider", Role: "admin"}
+
+	aliceAdmin := alice
+	aliceAdmin.Role = "admin"
+	carolRenamed := carol
+	carolRenamed.DisplayName = "Carol Renamed"
+
+	before := &border0client.Users{List: []border0client.User{alice, bob, outsider}}
+	created := &border0client.Users{List: []border0client.User{aliceAdmin, bob, carol, outsider}}
+	updated := &border0client.Users{List: []border0client.User{aliceAdmin, carolRenamed, outsider}}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (reconcile + read + read), alice's role is changed, bob is adopted as is and carol is created
+		clientMock.EXPECT().Users(matchContext).Return(before, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(created, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(created, nil).Call,
+
+		// this read is needed because of the update
+		clientMock.EXPECT().Users(matchContext).Return(created, nil).Call,
+
+		// terraform apply (reconcile + read + read), bob is removed from the roster and pruned, carol is renamed
+		clientMock.EXPECT().Users(matchContext).Return(created, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(updated, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(updated, nil).Call,
+	)
+
+	// the users are reconciled concurrently, so these calls can happen in any order
+	clientMock.EXPECT().UpdateUser(matchContext, &aliceAdmin).Return(&aliceAdmin, nil).Once()
+	clientMock.EXPECT().CreateUser(matchContext, &border0client.User{Email: carol.Email, DisplayName: carol.DisplayName, Role: carol.Role}, mock.Anything).Return(&carol, nil).Once()
+	clientMock.EXPECT().UpdateUser(matchContext, &carolRenamed).Return(&carolRenamed, nil).Once()
+	clientMock.EXPECT().DeleteUser(matchContext, bob.ID).Return(nil).Once()
+
+	// terraform destroy (delete), the remaining users are pruned, the outsider is never touched
+	clientMock.EXPECT().DeleteUser(matchContext, aliceAdmin.ID).Return(nil).Once()
+	clientMock.EXPECT().DeleteUser(matchContext, carolRenamed.ID).Return(nil).Once()
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "border0_user_roster" "unit_test" {
+					prune           = true
+					notify_by_email = false
+					user {
+						email        = "alice@unit-test.com"
+						display_name = "Alice"
+						role         = "admin"
+					}
+					user {
+						email        = "bob@unit-test.com"
+						display_name = "Bob"
+						role         = "member"
+					}
+					user {
+						email        = "carol@unit-test.com"
+						display_name = "Carol"
+						role         = "member"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user.#", "3"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.%", "3"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.alice@unit-test.com", alice.ID),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.bob@unit-test.com", bob.ID),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.carol@unit-test.com", carol.ID),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.%", "3"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.alice@unit-test.com", "updated"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.bob@unit-test.com", "unchanged"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.carol@unit-test.com", "created"),
+				),
+			},
+			{
+				Config: `
+				resource "border0_user_roster" "unit_test" {
+					prune           = true
+					notify_by_email = false
+					user {
+						email        = "alice@unit-test.com"
+						display_name = "Alice"
+						role         = "admin"
+					}
+					user {
+						email        = "carol@unit-test.com"
+						display_name = "Carol Renamed"
+						role         = "member"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("border0_user_roster.unit_test", "user.*", map[string]string{
+						"email":        "carol@unit-test.com",
+						"display_name": "Carol Renamed",
+					}),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.%", "2"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.%", "3"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.alice@unit-test.com", "unchanged"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.bob@unit-test.com", "deleted"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.carol@unit-test.com", "updated"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0UserRoster_FailedUser(t *testing.T) {
+	clientMock := mocks.APIClientRequester{}
+
+	// the failed user is never created, so it's missing on every read
+	clientMock.EXPECT().Users(matchContext).Return(&border0client.Users{}, nil)
+	clientMock.EXPECT().CreateUser(matchContext, &border0client.User{Email: "dave@unit-test.com", DisplayName: "Dave", Role: "member"}).
+		Return(nil, errors.New("quota exceeded")).Once()
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				// the failure is a warning, so the roster isn't tainted, and the user is created again on the next apply
+				Config: `
+				resource "border0_user_roster" "unit_test" {
+					user {
+						email        = "dave@unit-test.com"
+						display_name = "Dave"
+						role         = "member"
+					}
+				}`,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user.#", "0"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.%", "0"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.dave@unit-test.com", "failed"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0UserRoster_FailedPrune(t *testing.T) {
+	alice := border0client.User{ID: "unit-test-user-id-1", Email: "alice@unit-test.com", DisplayName: "Alice", Role: "member"}
+	bob := border0client.User{ID: "unit-test-user-id-2", Email: "bob@unit-test.com", DisplayName: "Bob", Role: "member"}
+
+	both := &border0client.Users{List: []border0client.User{alice, bob}}
+	pruned := &border0client.Users{List: []border0client.User{alice}}
+
+	clientMock := mocks.APIClientRequester{}
+	mockCallsInOrder(
+		// terraform apply (reconcile + read + read), alice and bob are created
+		clientMock.EXPECT().Users(matchContext).Return(&border0client.Users{}, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(both, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(both, nil).Call,
+
+		// this read is needed because of the update
+		clientMock.EXPECT().Users(matchContext).Return(both, nil).Call,
+
+		// terraform apply (reconcile + read), bob fails to be pruned
+		clientMock.EXPECT().Users(matchContext).Return(both, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(both, nil).Call,
+
+		// this read is needed because of the failed update
+		clientMock.EXPECT().Users(matchContext).Return(both, nil).Call,
+
+		// terraform apply (reconcile + read + read), bob is still in the state, so his deletion is retried
+		clientMock.EXPECT().Users(matchContext).Return(both, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(pruned, nil).Call,
+		clientMock.EXPECT().Users(matchContext).Return(pruned, nil).Call,
+	)
+
+	// the users are reconciled concurrently, so these calls can happen in any order
+	clientMock.EXPECT().CreateUser(matchContext, &border0client.User{Email: alice.Email, DisplayName: alice.DisplayName, Role: alice.Role}).Return(&alice, nil).Once()
+	clientMock.EXPECT().CreateUser(matchContext, &border0client.User{Email: bob.Email, DisplayName: bob.DisplayName, Role: bob.Role}).Return(&bob, nil).Once()
+	clientMock.EXPECT().DeleteUser(matchContext, bob.ID).Return(errors.New("internal server error")).Once()
+	clientMock.EXPECT().DeleteUser(matchContext, bob.ID).Return(nil).Once()
+
+	// terraform destroy (delete)
+	clientMock.EXPECT().DeleteUser(matchContext, alice.ID).Return(nil).Once()
+
+	config := `
+	resource "border0_user_roster" "unit_test" {
+		prune = true
+		user {
+			email        = "alice@unit-test.com"
+			display_name = "Alice"
+			role         = "member"
+		}
+	}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &clientMock),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "border0_user_roster" "unit_test" {
+					prune = true
+					user {
+						email        = "alice@unit-test.com"
+						display_name = "Alice"
+						role         = "member"
+					}
+					user {
+						email        = "bob@unit-test.com"
+						display_name = "Bob"
+						role         = "member"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.%", "2"),
+				),
+			},
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Failed to reconcile user bob@unit-test.com`),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user.#", "1"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "user_ids.%", "1"),
+					resource.TestCheckResourceAttr("border0_user_roster.unit_test", "results.bob@unit-test.com", "deleted"),
+				),
+			},
+		},
+	})
+}
+
+func Test_Resource_Border0UserRoster_DuplicateEmail(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:        true,
+		ProviderFactories: testProviderFactories(t, &mocks.APIClientRequester{}),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "border0_user_roster" "unit_test" {
+					user {
+						email        = "erin@unit-test.com"
+						display_name = "Erin"
+						role         = "member"
+					}
+					user {
+						email        = "Erin@unit-test.com"
+						display_name = "Erin"
+						role         = "admin"
+					}
+				}`,
+				ExpectError: regexp.MustCompile(`email "erin@unit-test.com" is listed more than once in the user roster`),
+			},
+		},
+	})
+}
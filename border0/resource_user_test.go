@@ -2,6 +2,7 @@ package border0_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	border0client "github.com/borderzero/border0-go/client"
@@ -73,6 +74,13 @@ func Test_Resource_Border0User(t *testing.T) {
 		// terraform import (read)
 		clientMock.EXPECT().User(matchContext, updateInputOutput.ID).Return(&updateInputOutput, nil).Call,
 
+		// terraform import by email (import + read)
+		clientMock.EXPECT().Users(matchContext).Return(&border0client.Users{List: []border0client.User{updateInputOutput}}, nil).Call,
+		clientMock.EXPECT().User(matchContext, updateInputOutput.ID).Return(&updateInputOutput, nil).Call,
+
+		// terraform import by unknown email (import)
+		clientMock.EXPECT().Users(matchContext).Return(&border0client.Users{List: []border0client.User{updateInputOutput}}, nil).Call,
+
 		// terraform destroy (delete)
 		clientMock.EXPECT().DeleteUser(matchContext, updateInputOutput.ID).Return(nil).Call,
 	)
@@ -105,6 +113,21 @@ func Test_Resource_Border0User(t *testing.T) {
 				ResourceName:      "border0_user.unit_test",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// imported users always get the default notify_by_email
+				ImportStateVerifyIgnore: []string{"notify_by_email"},
+			},
+			{
+				ResourceName:            "border0_user.unit_test",
+				ImportState:             true,
+				ImportStateId:           "User@Unit-Test.com",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"notify_by_email"},
+			},
+			{
+				ResourceName:  "border0_user.unit_test",
+				ImportState:   true,
+				ImportStateId: "nobody@unit-test.com",
+				ExpectError:   regexp.MustCompile(`user with email "nobody@unit-test.com" not found`),
 			},
 		},
 	})
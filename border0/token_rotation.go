@@ -0,0 +1,124 @@
+package border0
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tokenRotationAttributes returns the attributes that rotate connector and service account tokens.
+func tokenRotationAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"rotation_period": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateDuration,
+			Description:  "How long after its creation the token should be replaced, as a duration e.g. `720h`. Leave empty to never rotate the token based on its age.",
+		},
+		"rotate_before_expiry": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateDuration,
+			RequiredWith: []string{"expires_at"},
+			Description:  "How long before `expires_at` the token is due for rotation, as a duration e.g. `72h`. Requires `expires_at`, which replaces the token when it changes. Plans fail once an unchanged `expires_at` is within this duration, since a new token would expire at the same time, so move `expires_at` forward before then, e.g. by deriving it from a `time_rotating` resource.",
+		},
+		"keepers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Arbitrary values that replace the token when they change, e.g. the version of a secret that the token is stored in.",
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time (RFC 3339) at which the token was created.",
+		},
+		"ready_for_rotation": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the token is due for rotation. It's only `true` in plans, where it forces the replacement of the token. Add `create_before_destroy` to the resource's `lifecycle` so the new token exists before the old one is deleted.",
+		},
+	}
+}
+
+// customizeDiffTokenRotation replaces the token once it's past its rotation period. A token within
+// rotate before expiry fails the plan instead, unless expires_at changes and replaces it anyway: the
+// replacement would keep the same expires_at, and so would be due again on every plan.
+func customizeDiffTokenRotation(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if !d.HasChange("expires_at") && d.NewValueKnown("expires_at") {
+		expiresAt, rotateBeforeExpiry := d.Get("expires_at").(string), d.Get("rotate_before_expiry").(string)
+		expiring, err := tokenExpiresWithin(expiresAt, rotateBeforeExpiry, time.Now())
+		if err != nil {
+			return err
+		}
+		if expiring {
+			return fmt.Errorf(
+				"expires_at %q is within rotate_before_expiry (%s), a new token would expire at the same time and be replaced on every plan, move expires_at forward, e.g. by deriving it from a time_rotating resource",
+				expiresAt, rotateBeforeExpiry,
+			)
+		}
+	}
+
+	readyForRotation, err := tokenReadyForRotation(d.Get("created_at").(string), d.Get("rotation_period").(string), time.Now())
+	if err != nil {
+		return err
+	}
+	if !readyForRotation {
+		return nil
+	}
+	// the state is always false, so setting it to true is a change that can force the replacement
+	if err := d.SetNew("ready_for_rotation", true); err != nil {
+		return err
+	}
+	return d.ForceNew("ready_for_rotation")
+}
+
+// tokenReadyForRotation returns whether the token is older than the rotation period. Empty values
+// disable the check.
+func tokenReadyForRotation(createdAt, rotationPeriod string, now time.Time) (bool, error) {
+	if createdAt == "" || rotationPeriod == "" {
+		return false, nil
+	}
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return false, fmt.Errorf("invalid created_at %q: %w", createdAt, err)
+	}
+	period, err := time.ParseDuration(rotationPeriod)
+	if err != nil {
+		return false, fmt.Errorf("invalid rotation_period %q: %w", rotationPeriod, err)
+	}
+	return period > 0 && !now.Before(created.Add(period)), nil
+}
+
+// tokenExpiresWithin returns whether the token expires within rotate before expiry. Empty values
+// disable the check.
+func tokenExpiresWithin(expiresAt, rotateBeforeExpiry string, now time.Time) (bool, error) {
+	if expiresAt == "" || rotateBeforeExpiry == "" {
+		return false, nil
+	}
+	expires, err := border0client.FlexibleTimeFrom(expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("invalid expires_at %q: %w", expiresAt, err)
+	}
+	window, err := time.ParseDuration(rotateBeforeExpiry)
+	if err != nil {
+		return false, fmt.Errorf("invalid rotate_before_expiry %q: %w", rotateBeforeExpiry, err)
+	}
+	return !expires.IsZero() && !now.Before(expires.Add(-window)), nil
+}
+
+// tokenCreatedAt formats the creation time of a token, the zero time is returned as an empty string.
+func tokenCreatedAt(createdAt border0client.FlexibleTime) string {
+	if createdAt.IsZero() {
+		return ""
+	}
+	return createdAt.UTC().Format(time.RFC3339)
+}
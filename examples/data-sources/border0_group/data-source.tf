@@ -0,0 +1,9 @@
+// look up a group that is not managed by terraform by display name...
+data "border0_group" "engineering" {
+  display_name = "engineering"
+}
+
+// ...and report its members
+output "engineering_member_emails" {
+  value = data.border0_group.engineering.member_emails
+}
@@ -0,0 +1,6 @@
+// list all groups in the organization with their members
+data "border0_groups" "all" {}
+
+output "group_members" {
+  value = { for group in data.border0_groups.all.groups : group.id => group.member_emails }
+}
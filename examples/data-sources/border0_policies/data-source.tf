@@ -0,0 +1,9 @@
+// list all non-org-wide policies owned by the platform team
+data "border0_policies" "platform" {
+  name_prefix = "platform-"
+  org_wide    = false
+}
+
+output "platform_policy_ids" {
+  value = data.border0_policies.platform.ids
+}
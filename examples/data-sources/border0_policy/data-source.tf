@@ -0,0 +1,10 @@
+// look up an org-managed policy by name...
+data "border0_policy" "baseline" {
+  name = "org-baseline"
+}
+
+// ...and attach it to a terraform-managed socket
+resource "border0_policy_attachment" "baseline" {
+  policy_id = data.border0_policy.baseline.id
+  socket_id = border0_socket.example.id
+}
@@ -0,0 +1,49 @@
+data "border0_policy_v2_document" "engineering" {
+  permissions {
+    ssh {
+      allowed = true
+      shell {
+        allowed = true
+      }
+      use_allowed_usernames_list = true
+      allowed_usernames          = ["ubuntu"]
+    }
+  }
+  condition {
+    who {
+      group = [border0_group.engineering.id]
+    }
+    where {
+      allowed_ip = ["10.0.0.0/8"]
+    }
+    when {
+      time_of_day_after  = "08:00 UTC"
+      time_of_day_before = "18:00 UTC"
+    }
+  }
+}
+
+# Evaluating an access request against the policy before it is applied
+data "border0_policy_evaluation" "engineer_shell_as_root" {
+  policy {
+    name        = "engineering"
+    policy_data = data.border0_policy_v2_document.engineering.json
+  }
+
+  request {
+    email        = "johndoe@example.com"
+    groups       = [border0_group.engineering.id]
+    source_ip    = "10.1.2.3"
+    timestamp    = "2024-06-03T09:30:00Z"
+    socket_type  = "ssh"
+    ssh_action   = "shell"
+    ssh_username = "root"
+  }
+}
+
+check "engineers_cannot_log_in_as_root" {
+  assert {
+    condition     = !data.border0_policy_evaluation.engineer_shell_as_root.allowed
+    error_message = data.border0_policy_evaluation.engineer_shell_as_root.reason
+  }
+}
@@ -0,0 +1,31 @@
+# Migrating a policy written with the deprecated border0_policy_document
+data "border0_policy_document" "legacy" {
+  action = ["database", "ssh", "http", "tls"]
+  condition {
+    who {
+      email = ["johndoe@example.com"]
+    }
+    where {
+      allowed_ip = ["0.0.0.0/0", "::/0"]
+    }
+    when {
+      time_of_day_after  = "00:00 UTC"
+      time_of_day_before = "23:59 UTC"
+    }
+  }
+}
+
+data "border0_policy_v1_to_v2" "legacy" {
+  policy_data = data.border0_policy_document.legacy.json
+}
+
+resource "border0_policy" "migrated" {
+  name        = "migrated-policy"
+  version     = "v2"
+  policy_data = data.border0_policy_v1_to_v2.legacy.json
+}
+
+# Parts of the v1 policy that could not be converted exactly
+output "lossy_mappings" {
+  value = data.border0_policy_v1_to_v2.legacy.lossy_mappings
+}
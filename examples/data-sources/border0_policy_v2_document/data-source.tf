@@ -52,3 +52,23 @@ resource "border0_policy" "example" {
   name        = "example-policy"
   policy_data = data.border0_policy_v2_document.example.json
 }
+
+# Layering team-specific permissions on top of the example document
+data "border0_policy_v2_document" "team" {
+  source_policy_documents = [data.border0_policy_v2_document.example.json]
+
+  condition {
+    who {
+      group = ["4e3ef8c6-7a0f-4b5e-9f5e-0f3c1b7f1c2a"]
+    }
+    where {}
+    when {}
+  }
+
+  # shorten ssh sessions for this team, overrides win on scalars
+  override_policy_documents = [
+    jsonencode({
+      "permissions" : { "ssh" : { "max_session_duration_seconds" : 3600 } }
+    })
+  ]
+}
@@ -0,0 +1,13 @@
+# Reading an existing policy authored in the portal
+data "border0_policy" "portal" {
+  name = "portal-authored-policy"
+}
+
+data "border0_policy_v2_document_parse" "portal" {
+  json = data.border0_policy.portal.policy_data
+}
+
+# Auditing who has access
+output "portal_policy_emails" {
+  value = data.border0_policy_v2_document_parse.portal.condition[0].who[0].email
+}
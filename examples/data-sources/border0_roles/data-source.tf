@@ -0,0 +1,6 @@
+// list the roles that can be assigned to users and service accounts
+data "border0_roles" "all" {}
+
+output "roles" {
+  value = { for role in data.border0_roles.all.roles : role.name => role.description }
+}
@@ -0,0 +1,10 @@
+// look up a user that is not managed by terraform by email...
+data "border0_user" "johndoe" {
+  email = "johndoe@example.com"
+}
+
+// ...and add them to a terraform-managed group
+resource "border0_group" "example" {
+  display_name = "example-group"
+  members      = [data.border0_user.johndoe.id]
+}
@@ -0,0 +1,9 @@
+// list all admins with an example.com email address
+data "border0_users" "admins" {
+  role         = "admin"
+  email_domain = "example.com"
+}
+
+output "admin_emails" {
+  value = [for user in data.border0_users.admins.users : user.email]
+}
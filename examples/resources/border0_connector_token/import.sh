@@ -0,0 +1,3 @@
+# connector tokens can be imported using the connector id and the token id separated by a colon,
+# a colon within either of them must be escaped as %3A
+terraform import border0_connector_token.example <connector_id>:<token_id>
@@ -24,3 +24,16 @@ resource "border0_connector_token" "example_token_expires" {
     command = "echo 'token: ${self.token}' > ./border0.yaml"
   }
 }
+
+// or create a connector token that is replaced every 30 days, the new token
+// is created before the old one is deleted, so the connector can be updated
+// with the new token before the old one stops working
+resource "border0_connector_token" "example_token_rotated" {
+  connector_id    = border0_connector.example.id
+  name            = "example-connector-token-rotated"
+  rotation_period = "720h"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,5 @@
+# groups can be imported using the group id
+terraform import border0_group.example <group_id>
+
+# or using the group's display name, when it is not used by more than one group
+terraform import border0_group.example "SRE Team"
@@ -0,0 +1,2 @@
+# group memberships can be imported using the group id and the user id separated by a colon
+terraform import border0_group_membership.example <group_id>:<user_id>
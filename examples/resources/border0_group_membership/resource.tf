@@ -0,0 +1,17 @@
+// look up a shared group that is managed elsewhere...
+data "border0_group_names_to_ids" "shared" {
+  names = ["platform-oncall"]
+}
+
+// ...and a user that is managed by this configuration
+resource "border0_user" "example" {
+  display_name = "Example User"
+  email        = "example@example.com"
+  role         = "member"
+}
+
+// then add the user to the shared group, without touching its other members
+resource "border0_group_membership" "example" {
+  group_id = tolist(data.border0_group_names_to_ids.shared.ids)[0]
+  user_id  = border0_user.example.id
+}
@@ -55,3 +55,48 @@ resource "border0_policy" "example" {
     { example_tag = "example-value" },
   ]
 }
+
+// Using structured tag_rule blocks, the order of the blocks and of the values doesn't matter
+resource "border0_policy" "tagged" {
+  name        = "tagged-policy"
+  description = "My structured tag based policy"
+  version     = "v2"
+  policy_data = data.border0_policy_v2_document.example.json
+  // This results in (team:backend AND (environment:staging OR environment:production))
+  tag_rule {
+    key    = "team"
+    values = ["backend"]
+  }
+  tag_rule {
+    key      = "environment"
+    operator = "any_of"
+    values   = ["staging", "production"]
+  }
+}
+
+// Using the structured policy_v2 blocks instead of a JSON policy_data, plans show per-field diffs
+resource "border0_policy" "structured" {
+  name        = "structured-policy"
+  description = "My structured policy"
+  version     = "v2"
+  policy_v2 {
+    permissions {
+      ssh {
+        allowed                      = true
+        max_session_duration_seconds = 3600
+        shell {
+          allowed = true
+        }
+      }
+    }
+    condition {
+      who {
+        email = ["johndoe@example.com"]
+      }
+      where {
+        allowed_ip = ["10.0.0.0/8"]
+      }
+      when {}
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# policy attachments can be imported using the policy id and the socket id separated by a colon
+terraform import border0_policy_attachment.example <policy_id>:<socket_id>
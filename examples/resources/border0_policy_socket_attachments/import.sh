@@ -0,0 +1,2 @@
+# policy socket attachments can be imported using the policy id
+terraform import border0_policy_socket_attachments.example <policy_id>
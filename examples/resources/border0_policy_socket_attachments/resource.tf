@@ -0,0 +1,9 @@
+// attach the policy to exactly these sockets, any other
+// sockets the policy is attached to will be detached
+resource "border0_policy_socket_attachments" "example" {
+  policy_id = border0_policy.example.id
+  socket_ids = [
+    border0_socket.example_http.id,
+    border0_socket.example_ssh.id,
+  ]
+}
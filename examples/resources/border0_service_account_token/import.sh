@@ -0,0 +1,5 @@
+# service account tokens can be imported using the service account name and the token id separated by a colon,
+# a colon within either of them must be escaped as %3A
+# NOTE: this is a breaking change, ids used to be <token_id>:<service_account_name>, such ids are still
+# accepted when no service account has the name of the token id
+terraform import border0_service_account_token.example <service_account_name>:<token_id>
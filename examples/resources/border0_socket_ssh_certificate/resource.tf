@@ -0,0 +1,14 @@
+// sign the public key of a break-glass automation host
+resource "border0_socket_ssh_certificate" "break_glass" {
+  socket_id  = border0_socket.example_ssh.id
+  public_key = file("~/.ssh/id_ed25519.pub")
+
+  // re-sign the certificate 2 hours before it expires
+  renew_before = "2h"
+}
+
+// and write it next to the private key
+resource "local_file" "break_glass_certificate" {
+  filename = pathexpand("~/.ssh/id_ed25519-cert.pub")
+  content  = border0_socket_ssh_certificate.break_glass.certificate
+}
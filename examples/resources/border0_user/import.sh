@@ -0,0 +1,5 @@
+# users can be imported using the user id
+terraform import border0_user.example <user_id>
+
+# or using the user's email address, the user gets the default notify_by_email value of true
+terraform import border0_user.example alice@example.com
@@ -0,0 +1,22 @@
+// manage the users listed in an HR export, e.g. a CSV file with the columns email, name and role
+locals {
+  employees = csvdecode(file("${path.module}/employees.csv"))
+}
+
+resource "border0_user_roster" "employees" {
+  dynamic "user" {
+    for_each = local.employees
+    content {
+      email        = user.value.email
+      display_name = user.value.name
+      role         = user.value.role
+    }
+  }
+
+  // delete the users that leave the company, instead of only no longer managing them
+  prune = true
+}
+
+output "employee_user_ids" {
+  value = border0_user_roster.employees.user_ids
+}
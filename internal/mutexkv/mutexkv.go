@@ -0,0 +1,36 @@
+// Package mutexkv provides mutexes keyed by string, e.g. to serialize
+// read-modify-write operations on the same remote object across resources.
+package mutexkv
+
+import "sync"
+
+// MutexKV is a set of mutexes, one per key. The zero value is ready to use.
+type MutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+// Lock locks the mutex for the given key, creating it if needed.
+func (m *MutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+// Unlock unlocks the mutex for the given key.
+func (m *MutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *MutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.store == nil {
+		m.store = map[string]*sync.Mutex{}
+	}
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
@@ -0,0 +1,58 @@
+package mutexkv
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutexKV(t *testing.T) {
+	var m MutexKV
+	var wg sync.WaitGroup
+	counters := map[string]int{}
+	var countersLock sync.Mutex
+
+	for _, key := range []string{"a", "b"} {
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.Lock(key)
+				defer m.Unlock(key)
+
+				// a read-modify-write that would lose updates without the lock
+				countersLock.Lock()
+				value := counters[key]
+				countersLock.Unlock()
+				time.Sleep(time.Microsecond)
+				countersLock.Lock()
+				counters[key] = value + 1
+				countersLock.Unlock()
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{"a": 50, "b": 50}, counters)
+}
+
+func TestMutexKVIndependentKeys(t *testing.T) {
+	var m MutexKV
+	m.Lock("a")
+	defer m.Unlock("a")
+
+	locked := make(chan struct{})
+	go func() {
+		m.Lock("b")
+		defer m.Unlock("b")
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("locking a different key blocked")
+	}
+}
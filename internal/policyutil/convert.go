@@ -0,0 +1,64 @@
+package policyutil
+
+import (
+	"fmt"
+	"strings"
+
+	border0client "github.com/borderzero/border0-go/client"
+)
+
+// ConvertV1ToV2 translates a v1 policy into the equivalent v2 policy. A v1 action grants full access
+// to sockets of that type, so it maps to the v2 permission with every sub-permission allowed and no
+// allow lists. The returned notes describe the parts of the v1 policy that have no exact v2 mapping.
+func ConvertV1ToV2(policyData border0client.PolicyData) (border0client.PolicyDataV2, []string) {
+	var notes []string
+	var permissions border0client.PolicyPermissions
+
+	for _, action := range policyData.Action {
+		switch strings.ToLower(action) {
+		case SocketTypeSSH:
+			permissions.SSH = &border0client.SSHPermissions{
+				Shell:         &border0client.SSHShellPermission{},
+				Exec:          &border0client.SSHExecPermission{},
+				SFTP:          &border0client.SSHSFTPPermission{},
+				TCPForwarding: &border0client.SSHTCPForwardingPermission{},
+				KubectlExec:   &border0client.SSHKubectlExecPermission{},
+				DockerExec:    &border0client.SSHDockerExecPermission{},
+			}
+		case SocketTypeDatabase:
+			permissions.Database = &border0client.DatabasePermissions{}
+		case SocketTypeHTTP:
+			permissions.HTTP = &border0client.HTTPPermissions{}
+		case SocketTypeKubernetes:
+			permissions.Kubernetes = &border0client.KubernetesPermissions{}
+		case SocketTypeTLS:
+			permissions.TLS = &border0client.TLSPermissions{}
+		case SocketTypeVNC:
+			permissions.VNC = &border0client.VNCPermissions{}
+		case SocketTypeRDP:
+			permissions.RDP = &border0client.RDPPermissions{}
+		case SocketTypeNetwork:
+			permissions.Network = &border0client.NetworkPermissions{}
+		default:
+			notes = append(notes, fmt.Sprintf("action %q has no v2 equivalent and was dropped", action))
+		}
+	}
+
+	who := policyData.Condition.Who
+	if len(who.Domain) > 0 {
+		notes = append(notes, fmt.Sprintf("who.domain %q has no v2 equivalent and was dropped, list the users in who.email or add them to a group in who.group instead", who.Domain))
+	}
+
+	return border0client.PolicyDataV2{
+		Permissions: permissions,
+		Condition: border0client.PolicyConditionV2{
+			Who: border0client.PolicyWhoV2{
+				Email:          who.Email,
+				Group:          who.Group,
+				ServiceAccount: who.ServiceAccount,
+			},
+			Where: policyData.Condition.Where,
+			When:  policyData.Condition.When,
+		},
+	}, notes
+}
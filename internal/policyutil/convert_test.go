@@ -0,0 +1,67 @@
+package policyutil
+
+import (
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertV1ToV2(t *testing.T) {
+	policyData := border0client.PolicyData{
+		Version: "v1",
+		Action:  []string{"database", "ssh", "http", "tls"},
+		Condition: border0client.PolicyCondition{
+			Who: border0client.PolicyWho{
+				Email:          []string{"johndoe@example.com"},
+				Group:          []string{"group-id"},
+				ServiceAccount: []string{"ci"},
+			},
+			Where: border0client.PolicyWhere{AllowedIP: []string{"0.0.0.0/0", "::/0"}, Country: []string{"NL"}},
+			When:  border0client.PolicyWhen{After: "2022-10-13T05:12:26Z", TimeOfDayAfter: "00:00 UTC", TimeOfDayBefore: "23:59 UTC"},
+		},
+	}
+
+	converted, notes := ConvertV1ToV2(policyData)
+
+	assert.Empty(t, notes)
+	assert.Equal(t, border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Database: &border0client.DatabasePermissions{},
+			SSH: &border0client.SSHPermissions{
+				Shell:         &border0client.SSHShellPermission{},
+				Exec:          &border0client.SSHExecPermission{},
+				SFTP:          &border0client.SSHSFTPPermission{},
+				TCPForwarding: &border0client.SSHTCPForwardingPermission{},
+				KubectlExec:   &border0client.SSHKubectlExecPermission{},
+				DockerExec:    &border0client.SSHDockerExecPermission{},
+			},
+			HTTP: &border0client.HTTPPermissions{},
+			TLS:  &border0client.TLSPermissions{},
+		},
+		Condition: border0client.PolicyConditionV2{
+			Who: border0client.PolicyWhoV2{
+				Email:          []string{"johndoe@example.com"},
+				Group:          []string{"group-id"},
+				ServiceAccount: []string{"ci"},
+			},
+			Where: policyData.Condition.Where,
+			When:  policyData.Condition.When,
+		},
+	}, converted)
+}
+
+func TestConvertV1ToV2_LossyMappings(t *testing.T) {
+	policyData := border0client.PolicyData{Action: []string{"SSH", "ftp"}}
+	policyData.Condition.Who.Email = []string{"johndoe@example.com"}
+	policyData.Condition.Who.Domain = []string{"example.com"}
+
+	converted, notes := ConvertV1ToV2(policyData)
+
+	assert.NotNil(t, converted.Permissions.SSH)
+	assert.Equal(t, []string{"johndoe@example.com"}, converted.Condition.Who.Email)
+	assert.Equal(t, []string{
+		`action "ftp" has no v2 equivalent and was dropped`,
+		`who.domain ["example.com"] has no v2 equivalent and was dropped, list the users in who.email or add them to a group in who.group instead`,
+	}, notes)
+}
@@ -0,0 +1,358 @@
+package policyutil
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	border0client "github.com/borderzero/border0-go/client"
+)
+
+// Socket types that can be evaluated, matching the permissions of a v2 policy.
+const (
+	SocketTypeSSH        = "ssh"
+	SocketTypeDatabase   = "database"
+	SocketTypeHTTP       = "http"
+	SocketTypeKubernetes = "kubernetes"
+	SocketTypeTLS        = "tls"
+	SocketTypeVNC        = "vnc"
+	SocketTypeRDP        = "rdp"
+	SocketTypeNetwork    = "network"
+)
+
+// SocketTypes is the list of socket types that can be evaluated.
+var SocketTypes = []string{
+	SocketTypeSSH,
+	SocketTypeDatabase,
+	SocketTypeHTTP,
+	SocketTypeKubernetes,
+	SocketTypeTLS,
+	SocketTypeVNC,
+	SocketTypeRDP,
+	SocketTypeNetwork,
+}
+
+// SSH actions that can be evaluated, matching the ssh permissions of a v2 policy.
+const (
+	SSHActionShell         = "shell"
+	SSHActionExec          = "exec"
+	SSHActionSFTP          = "sftp"
+	SSHActionTCPForwarding = "tcp_forwarding"
+	SSHActionKubectlExec   = "kubectl_exec"
+	SSHActionDockerExec    = "docker_exec"
+)
+
+// SSHActions is the list of ssh actions that can be evaluated.
+var SSHActions = []string{
+	SSHActionShell,
+	SSHActionExec,
+	SSHActionSFTP,
+	SSHActionTCPForwarding,
+	SSHActionKubectlExec,
+	SSHActionDockerExec,
+}
+
+// EvaluationRequest describes an access request to evaluate policies against.
+// Empty fields are treated as unknown, and never satisfy a condition that restricts them.
+type EvaluationRequest struct {
+	Email          string
+	Groups         []string
+	ServiceAccount string
+	SourceIP       string
+	Country        string
+	Time           time.Time
+	SocketType     string
+	SSHAction      string
+	SSHUsername    string
+	Database       string
+	QueryType      string
+}
+
+// NamedPolicy is a v2 policy with the name it is reported by in an evaluation decision.
+type NamedPolicy struct {
+	Name       string
+	PolicyData border0client.PolicyDataV2
+}
+
+// Decision is the outcome of evaluating policies against a request.
+type Decision struct {
+	// Allowed is true when at least one policy allows the request.
+	Allowed bool
+	// Policies are the names of the policies that allow the request.
+	Policies []string
+	// Reason explains the decision.
+	Reason string
+}
+
+// EvaluateV2 evaluates the request against the given v2 policies. Access is denied by default,
+// and allowed when any policy both matches the request's condition and grants the permission. A
+// policy with an empty who condition matches no requester, like the API treats it.
+func EvaluateV2(policies []NamedPolicy, request EvaluationRequest) Decision {
+	var allowedBy, denials []string
+	for _, policy := range policies {
+		if reason := denialReason(policy.PolicyData, request); reason != "" {
+			denials = append(denials, fmt.Sprintf("%s: %s", policy.Name, reason))
+			continue
+		}
+		allowedBy = append(allowedBy, policy.Name)
+	}
+
+	if len(allowedBy) > 0 {
+		return Decision{
+			Allowed:  true,
+			Policies: allowedBy,
+			Reason:   fmt.Sprintf("allowed by %s", strings.Join(allowedBy, ", ")),
+		}
+	}
+	if len(denials) == 0 {
+		return Decision{Reason: "denied, no policies to evaluate"}
+	}
+	return Decision{Reason: fmt.Sprintf("denied, %s", strings.Join(denials, "; "))}
+}
+
+// denialReason returns why the policy does not allow the request, or an empty string if it does.
+func denialReason(policyData border0client.PolicyDataV2, request EvaluationRequest) string {
+	condition := policyData.Condition
+	if reason := whoDenialReason(condition.Who, request); reason != "" {
+		return reason
+	}
+	if reason := whereDenialReason(condition.Where, request); reason != "" {
+		return reason
+	}
+	if reason := whenDenialReason(condition.When, request.Time); reason != "" {
+		return reason
+	}
+	return permissionsDenialReason(policyData.Permissions, request)
+}
+
+func whoDenialReason(who border0client.PolicyWhoV2, request EvaluationRequest) string {
+	if len(who.Email) == 0 && len(who.Group) == 0 && len(who.ServiceAccount) == 0 {
+		return "the who condition is empty, so the policy allows no one"
+	}
+	if request.Email != "" && slices.ContainsFunc(who.Email, func(email string) bool { return strings.EqualFold(email, request.Email) }) {
+		return ""
+	}
+	if slices.ContainsFunc(request.Groups, func(group string) bool { return slices.Contains(who.Group, group) }) {
+		return ""
+	}
+	if request.ServiceAccount != "" && slices.Contains(who.ServiceAccount, request.ServiceAccount) {
+		return ""
+	}
+	return "requester does not match the who condition"
+}
+
+func whereDenialReason(where border0client.PolicyWhere, request EvaluationRequest) string {
+	if len(where.AllowedIP) > 0 {
+		sourceIP, err := netip.ParseAddr(request.SourceIP)
+		if err != nil {
+			return "source IP is unknown, and the where condition restricts allowed_ip"
+		}
+		if !slices.ContainsFunc(where.AllowedIP, func(allowedIP string) bool { return ipMatches(allowedIP, sourceIP) }) {
+			return fmt.Sprintf("source IP %s is not in where.allowed_ip", request.SourceIP)
+		}
+	}
+
+	if len(where.Country) > 0 || len(where.CountryNot) > 0 {
+		if request.Country == "" {
+			return "country is unknown, and the where condition restricts countries"
+		}
+		sameCountry := func(country string) bool { return strings.EqualFold(country, request.Country) }
+		if len(where.Country) > 0 && !slices.ContainsFunc(where.Country, sameCountry) {
+			return fmt.Sprintf("country %s is not in where.country", request.Country)
+		}
+		if slices.ContainsFunc(where.CountryNot, sameCountry) {
+			return fmt.Sprintf("country %s is in where.country_not", request.Country)
+		}
+	}
+
+	return ""
+}
+
+func ipMatches(allowedIP string, sourceIP netip.Addr) bool {
+	if prefix, err := netip.ParsePrefix(allowedIP); err == nil {
+		return prefix.Contains(sourceIP)
+	}
+	if addr, err := netip.ParseAddr(allowedIP); err == nil {
+		return addr == sourceIP
+	}
+	return false
+}
+
+func whenDenialReason(when border0client.PolicyWhen, at time.Time) string {
+	if when.After != "" {
+		if after, err := time.Parse(time.RFC3339, when.After); err != nil || at.Before(after) {
+			return fmt.Sprintf("request time is not after %s", when.After)
+		}
+	}
+	if when.Before != "" {
+		if before, err := time.Parse(time.RFC3339, when.Before); err != nil || !at.Before(before) {
+			return fmt.Sprintf("request time is not before %s", when.Before)
+		}
+	}
+
+	if when.TimeOfDayAfter == "" && when.TimeOfDayBefore == "" {
+		return ""
+	}
+	inWindow, err := inTimeOfDayWindow(when.TimeOfDayAfter, when.TimeOfDayBefore, at)
+	if err != nil {
+		return err.Error()
+	}
+	if !inWindow {
+		return fmt.Sprintf("request time is outside the time of day window %q to %q", when.TimeOfDayAfter, when.TimeOfDayBefore)
+	}
+	return ""
+}
+
+// inTimeOfDayWindow returns whether the given time falls in the time of day window. An empty bound
+// is open-ended, and a window whose start is later than its end wraps around midnight.
+func inTimeOfDayWindow(after, before string, at time.Time) (bool, error) {
+	start, startSet, err := parseTimeOfDay(after)
+	if err != nil {
+		return false, err
+	}
+	end, endSet, err := parseTimeOfDay(before)
+	if err != nil {
+		return false, err
+	}
+	afterStart := startSet && start.minutesOf(at) >= start.minutes
+	beforeEnd := endSet && end.minutesOf(at) < end.minutes
+
+	switch {
+	case startSet && endSet && start.minutes > end.minutes:
+		return afterStart || beforeEnd, nil
+	case startSet && endSet:
+		return afterStart && beforeEnd, nil
+	case startSet:
+		return afterStart, nil
+	default:
+		return beforeEnd, nil
+	}
+}
+
+type timeOfDay struct {
+	minutes  int
+	location *time.Location
+}
+
+// minutesOf returns the minutes since midnight of the given time, in the time of day's time zone.
+func (t timeOfDay) minutesOf(at time.Time) int {
+	local := at.In(t.location)
+	return local.Hour()*60 + local.Minute()
+}
+
+// parseTimeOfDay parses a "HH:MM" time of day, optionally followed by a time zone which defaults to UTC.
+func parseTimeOfDay(value string) (timeOfDay, bool, error) {
+	if value == "" {
+		return timeOfDay{}, false, nil
+	}
+	if err := ValidateTimeOfDay(value); err != nil {
+		return timeOfDay{}, false, err
+	}
+
+	clock, zone, _ := strings.Cut(value, " ")
+	location := time.UTC
+	if zone != "" {
+		var err error
+		if location, err = time.LoadLocation(zone); err != nil {
+			return timeOfDay{}, false, fmt.Errorf("unknown time zone %q in time of day %q", zone, value)
+		}
+	}
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return timeOfDay{}, false, fmt.Errorf("%q is not a valid time of day", value)
+	}
+	return timeOfDay{minutes: parsed.Hour()*60 + parsed.Minute(), location: location}, true, nil
+}
+
+func permissionsDenialReason(permissions border0client.PolicyPermissions, request EvaluationRequest) string {
+	notGranted := fmt.Sprintf("%s access is not granted", request.SocketType)
+
+	switch request.SocketType {
+	case SocketTypeSSH:
+		if permissions.SSH == nil {
+			return notGranted
+		}
+		return sshDenialReason(permissions.SSH, request)
+	case SocketTypeDatabase:
+		if permissions.Database == nil {
+			return notGranted
+		}
+		return databaseDenialReason(permissions.Database, request)
+	case SocketTypeHTTP:
+		return deniedUnless(permissions.HTTP != nil, notGranted)
+	case SocketTypeKubernetes:
+		return deniedUnless(permissions.Kubernetes != nil, notGranted)
+	case SocketTypeTLS:
+		return deniedUnless(permissions.TLS != nil, notGranted)
+	case SocketTypeVNC:
+		return deniedUnless(permissions.VNC != nil, notGranted)
+	case SocketTypeRDP:
+		return deniedUnless(permissions.RDP != nil, notGranted)
+	case SocketTypeNetwork:
+		return deniedUnless(permissions.Network != nil, notGranted)
+	default:
+		return fmt.Sprintf("unknown socket type %q", request.SocketType)
+	}
+}
+
+func sshDenialReason(ssh *border0client.SSHPermissions, request EvaluationRequest) string {
+	if ssh.AllowedUsernames != nil && !slices.Contains(*ssh.AllowedUsernames, request.SSHUsername) {
+		if request.SSHUsername == "" {
+			return "ssh username is unknown, and allowed_usernames is restricted"
+		}
+		return fmt.Sprintf("ssh username %q is not in allowed_usernames", request.SSHUsername)
+	}
+
+	if request.SSHAction == "" {
+		return ""
+	}
+	notGranted := fmt.Sprintf("ssh %s is not granted", request.SSHAction)
+	switch request.SSHAction {
+	case SSHActionShell:
+		return deniedUnless(ssh.Shell != nil, notGranted)
+	case SSHActionExec:
+		return deniedUnless(ssh.Exec != nil, notGranted)
+	case SSHActionSFTP:
+		return deniedUnless(ssh.SFTP != nil, notGranted)
+	case SSHActionTCPForwarding:
+		return deniedUnless(ssh.TCPForwarding != nil, notGranted)
+	case SSHActionKubectlExec:
+		return deniedUnless(ssh.KubectlExec != nil, notGranted)
+	case SSHActionDockerExec:
+		return deniedUnless(ssh.DockerExec != nil, notGranted)
+	default:
+		return fmt.Sprintf("unknown ssh action %q", request.SSHAction)
+	}
+}
+
+func databaseDenialReason(db *border0client.DatabasePermissions, request EvaluationRequest) string {
+	if db.AllowedDatabases == nil {
+		return ""
+	}
+	if request.Database == "" {
+		return "database is unknown, and allowed_databases is restricted"
+	}
+
+	for _, allowed := range *db.AllowedDatabases {
+		if allowed.Database != request.Database {
+			continue
+		}
+		if allowed.AllowedQueryTypes == nil || slices.Contains(*allowed.AllowedQueryTypes, request.QueryType) {
+			return ""
+		}
+		if request.QueryType == "" {
+			return fmt.Sprintf("query type is unknown, and allowed_query_types is restricted for database %q", request.Database)
+		}
+		return fmt.Sprintf("query type %q is not allowed for database %q", request.QueryType, request.Database)
+	}
+	return fmt.Sprintf("database %q is not in allowed_databases", request.Database)
+}
+
+func deniedUnless(granted bool, reason string) string {
+	if granted {
+		return ""
+	}
+	return reason
+}
@@ -0,0 +1,186 @@
+package policyutil
+
+import (
+	"testing"
+	"time"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func engineeringSSHPolicy() border0client.PolicyDataV2 {
+	usernames := []string{"ubuntu"}
+	return border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			SSH: &border0client.SSHPermissions{
+				Shell:            &border0client.SSHShellPermission{},
+				AllowedUsernames: &usernames,
+			},
+		},
+		Condition: border0client.PolicyConditionV2{
+			Who:   border0client.PolicyWhoV2{Group: []string{"engineering"}},
+			Where: border0client.PolicyWhere{AllowedIP: []string{"10.0.0.0/8"}, CountryNot: []string{"RU"}},
+			When:  border0client.PolicyWhen{After: "2024-01-01T00:00:00Z", TimeOfDayAfter: "08:00 UTC", TimeOfDayBefore: "18:00 UTC"},
+		},
+	}
+}
+
+func engineeringSSHRequest() EvaluationRequest {
+	return EvaluationRequest{
+		Email:       "johndoe@example.com",
+		Groups:      []string{"engineering"},
+		SourceIP:    "10.1.2.3",
+		Country:     "NL",
+		Time:        time.Date(2024, 6, 3, 9, 30, 0, 0, time.UTC),
+		SocketType:  SocketTypeSSH,
+		SSHAction:   SSHActionShell,
+		SSHUsername: "ubuntu",
+	}
+}
+
+func TestEvaluateV2_Allowed(t *testing.T) {
+	decision := EvaluateV2([]NamedPolicy{
+		{Name: "engineering", PolicyData: engineeringSSHPolicy()},
+		{Name: "nobody", PolicyData: border0client.PolicyDataV2{}},
+	}, engineeringSSHRequest())
+
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, []string{"engineering"}, decision.Policies)
+	assert.Equal(t, "allowed by engineering", decision.Reason)
+}
+
+func TestEvaluateV2_Denied(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*EvaluationRequest)
+		reason string
+	}{
+		{
+			name:   "who",
+			modify: func(r *EvaluationRequest) { r.Groups = []string{"sales"} },
+			reason: "requester does not match the who condition",
+		},
+		{
+			name:   "allowed ip",
+			modify: func(r *EvaluationRequest) { r.SourceIP = "192.168.1.1" },
+			reason: "source IP 192.168.1.1 is not in where.allowed_ip",
+		},
+		{
+			name:   "unknown source ip",
+			modify: func(r *EvaluationRequest) { r.SourceIP = "" },
+			reason: "source IP is unknown, and the where condition restricts allowed_ip",
+		},
+		{
+			name:   "country not",
+			modify: func(r *EvaluationRequest) { r.Country = "ru" },
+			reason: "country ru is in where.country_not",
+		},
+		{
+			name:   "after",
+			modify: func(r *EvaluationRequest) { r.Time = time.Date(2023, 6, 3, 9, 30, 0, 0, time.UTC) },
+			reason: "request time is not after 2024-01-01T00:00:00Z",
+		},
+		{
+			name:   "time of day",
+			modify: func(r *EvaluationRequest) { r.Time = time.Date(2024, 6, 3, 18, 0, 0, 0, time.UTC) },
+			reason: `request time is outside the time of day window "08:00 UTC" to "18:00 UTC"`,
+		},
+		{
+			name:   "socket type",
+			modify: func(r *EvaluationRequest) { r.SocketType = SocketTypeDatabase },
+			reason: "database access is not granted",
+		},
+		{
+			name:   "ssh action",
+			modify: func(r *EvaluationRequest) { r.SSHAction = SSHActionSFTP },
+			reason: "ssh sftp is not granted",
+		},
+		{
+			name:   "ssh username",
+			modify: func(r *EvaluationRequest) { r.SSHUsername = "root" },
+			reason: `ssh username "root" is not in allowed_usernames`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := engineeringSSHRequest()
+			test.modify(&request)
+
+			decision := EvaluateV2([]NamedPolicy{{Name: "engineering", PolicyData: engineeringSSHPolicy()}}, request)
+
+			assert.False(t, decision.Allowed)
+			assert.Empty(t, decision.Policies)
+			assert.Equal(t, "denied, engineering: "+test.reason, decision.Reason)
+		})
+	}
+}
+
+func TestEvaluateV2_EmptyWho(t *testing.T) {
+	policy := engineeringSSHPolicy()
+	policy.Condition.Who = border0client.PolicyWhoV2{}
+
+	decision := EvaluateV2([]NamedPolicy{{Name: "nobody", PolicyData: policy}}, engineeringSSHRequest())
+
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "denied, nobody: the who condition is empty, so the policy allows no one", decision.Reason)
+}
+
+func TestEvaluateV2_NoPolicies(t *testing.T) {
+	decision := EvaluateV2(nil, engineeringSSHRequest())
+
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "denied, no policies to evaluate", decision.Reason)
+}
+
+func TestEvaluateV2_Database(t *testing.T) {
+	readOnly := []string{"ReadOnly"}
+	allowedDatabases := []border0client.DatabasePermission{
+		{Database: "reporting", AllowedQueryTypes: &readOnly},
+		{Database: "app"},
+	}
+	policies := []NamedPolicy{{Name: "db", PolicyData: border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Database: &border0client.DatabasePermissions{AllowedDatabases: &allowedDatabases},
+		},
+		Condition: border0client.PolicyConditionV2{
+			Who: border0client.PolicyWhoV2{Email: []string{"JohnDoe@example.com"}},
+		},
+	}}}
+	request := func(database, queryType string) EvaluationRequest {
+		return EvaluationRequest{Email: "johndoe@example.com", SocketType: SocketTypeDatabase, Database: database, QueryType: queryType}
+	}
+
+	assert.True(t, EvaluateV2(policies, request("reporting", "ReadOnly")).Allowed)
+	assert.True(t, EvaluateV2(policies, request("app", "Write")).Allowed)
+	assert.Equal(t, `denied, db: query type "Write" is not allowed for database "reporting"`, EvaluateV2(policies, request("reporting", "Write")).Reason)
+	assert.Equal(t, `denied, db: database "billing" is not in allowed_databases`, EvaluateV2(policies, request("billing", "")).Reason)
+	assert.Equal(t, "denied, db: database is unknown, and allowed_databases is restricted", EvaluateV2(policies, request("", "")).Reason)
+}
+
+func TestInTimeOfDayWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2024, 6, 3, hour, minute, 0, 0, time.UTC) }
+
+	inWindow, err := inTimeOfDayWindow("22:00 UTC", "06:00 UTC", at(23, 0))
+	assert.NoError(t, err)
+	assert.True(t, inWindow, "window wrapping around midnight, before midnight")
+
+	inWindow, err = inTimeOfDayWindow("22:00 UTC", "06:00 UTC", at(5, 59))
+	assert.NoError(t, err)
+	assert.True(t, inWindow, "window wrapping around midnight, after midnight")
+
+	inWindow, err = inTimeOfDayWindow("22:00 UTC", "06:00 UTC", at(12, 0))
+	assert.NoError(t, err)
+	assert.False(t, inWindow, "window wrapping around midnight, midday")
+
+	inWindow, err = inTimeOfDayWindow("09:00", "", at(8, 59))
+	assert.NoError(t, err)
+	assert.False(t, inWindow, "open-ended window")
+
+	inWindow, err = inTimeOfDayWindow("", "17:00 Asia/Tokyo", at(7, 59))
+	assert.NoError(t, err)
+	assert.True(t, inWindow, "16:59 in Tokyo")
+
+	_, err = inTimeOfDayWindow("09:00 Mars/Olympus", "", at(8, 59))
+	assert.EqualError(t, err, `unknown time zone "Mars/Olympus" in time of day "09:00 Mars/Olympus"`)
+}
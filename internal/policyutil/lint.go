@@ -0,0 +1,69 @@
+package policyutil
+
+import (
+	"net/netip"
+
+	border0client "github.com/borderzero/border0-go/client"
+)
+
+// LintV2 returns warnings about risky, overly broad access granted by the given v2 policy.
+// Unlike ValidateCondition, the policy is valid and will be accepted as is.
+func LintV2(policyData border0client.PolicyDataV2, orgWide bool) []string {
+	var warnings []string
+	permissions := policyData.Permissions
+	condition := policyData.Condition
+
+	if orgWide && len(condition.Who.Email) == 0 && len(condition.Who.Group) == 0 && len(condition.Who.ServiceAccount) == 0 {
+		warnings = append(warnings, orgWideWithoutWhoWarning)
+	}
+
+	if ssh := permissions.SSH; ssh != nil {
+		if ssh.Exec != nil && ssh.Exec.Commands == nil {
+			warnings = append(warnings, "ssh exec is allowed without a commands list, any command can be executed")
+		}
+		if ssh.TCPForwarding != nil && ssh.TCPForwarding.AllowedConnections == nil {
+			warnings = append(warnings, "ssh tcp forwarding is allowed without an allowed connections list, connections to any destination can be forwarded")
+		}
+		if ssh.MaxSessionDurationSeconds == nil {
+			warnings = append(warnings, "ssh access is allowed without max_session_duration_seconds, sessions are not time limited")
+		}
+	}
+
+	if db := permissions.Database; db != nil && db.MaxSessionDurationSeconds == nil {
+		warnings = append(warnings, "database access is allowed without max_session_duration_seconds, sessions are not time limited")
+	}
+
+	if permissions.Network != nil && !isWhereRestricted(condition.Where) {
+		warnings = append(warnings, "network access is allowed without a where restriction, it can be used from any IP address and country")
+	}
+
+	return warnings
+}
+
+// LintV1 returns warnings about risky, overly broad access granted by the given v1 policy.
+func LintV1(policyData border0client.PolicyData, orgWide bool) []string {
+	var warnings []string
+	who := policyData.Condition.Who
+	if orgWide && len(who.Email) == 0 && len(who.Group) == 0 && len(who.Domain) == 0 && len(who.ServiceAccount) == 0 {
+		warnings = append(warnings, orgWideWithoutWhoWarning)
+	}
+	return warnings
+}
+
+// orgWideWithoutWhoWarning is about org-wide policies that allow no one, an empty who condition
+// matches no requester, the same way as the API and EvaluateV2 treat it.
+const orgWideWithoutWhoWarning = "the policy is org-wide but its who condition is empty, so it allows no one on any socket"
+
+// isWhereRestricted returns whether the where condition narrows down where requests can come from,
+// allowing every address with 0.0.0.0/0 or ::/0 is not considered a restriction.
+func isWhereRestricted(where border0client.PolicyWhere) bool {
+	if len(where.Country) > 0 || len(where.CountryNot) > 0 {
+		return true
+	}
+	for _, allowedIP := range where.AllowedIP {
+		if prefix, err := netip.ParsePrefix(allowedIP); err != nil || prefix.Bits() != 0 {
+			return true
+		}
+	}
+	return false
+}
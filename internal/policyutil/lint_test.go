@@ -0,0 +1,95 @@
+package policyutil
+
+import (
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintV2_NoWarnings(t *testing.T) {
+	maxSessionDuration := 3600
+	commands := []string{"whoami"}
+	connections := []border0client.SSHTcpForwardingConnection{}
+
+	warnings := LintV2(border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			SSH: &border0client.SSHPermissions{
+				Exec:                      &border0client.SSHExecPermission{Commands: &commands},
+				TCPForwarding:             &border0client.SSHTCPForwardingPermission{AllowedConnections: &connections},
+				MaxSessionDurationSeconds: &maxSessionDuration,
+			},
+			Database: &border0client.DatabasePermissions{MaxSessionDurationSeconds: &maxSessionDuration},
+			Network:  &border0client.NetworkPermissions{},
+		},
+		Condition: border0client.PolicyConditionV2{
+			Who:   border0client.PolicyWhoV2{Group: []string{"group-id"}},
+			Where: border0client.PolicyWhere{AllowedIP: []string{"10.0.0.0/8"}},
+		},
+	}, true)
+
+	assert.Empty(t, warnings)
+}
+
+func TestLintV2_Warnings(t *testing.T) {
+	warnings := LintV2(border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			SSH: &border0client.SSHPermissions{
+				Exec:          &border0client.SSHExecPermission{},
+				TCPForwarding: &border0client.SSHTCPForwardingPermission{},
+			},
+			Database: &border0client.DatabasePermissions{},
+			Network:  &border0client.NetworkPermissions{},
+		},
+		Condition: border0client.PolicyConditionV2{
+			Where: border0client.PolicyWhere{AllowedIP: []string{"0.0.0.0/0", "::/0"}},
+		},
+	}, true)
+
+	assert.Equal(t, []string{
+		orgWideWithoutWhoWarning,
+		"ssh exec is allowed without a commands list, any command can be executed",
+		"ssh tcp forwarding is allowed without an allowed connections list, connections to any destination can be forwarded",
+		"ssh access is allowed without max_session_duration_seconds, sessions are not time limited",
+		"database access is allowed without max_session_duration_seconds, sessions are not time limited",
+		"network access is allowed without a where restriction, it can be used from any IP address and country",
+	}, warnings)
+}
+
+func TestLintV2_NotOrgWide(t *testing.T) {
+	assert.Empty(t, LintV2(border0client.PolicyDataV2{}, false))
+	assert.Equal(t, []string{orgWideWithoutWhoWarning}, LintV2(border0client.PolicyDataV2{}, true))
+}
+
+func TestLintV2_EmptyWhoAllowsNoOne(t *testing.T) {
+	// the lint and the evaluation agree that an empty who condition allows no one
+	policy := border0client.PolicyDataV2{Permissions: border0client.PolicyPermissions{HTTP: &border0client.HTTPPermissions{}}}
+	assert.Equal(t, []string{orgWideWithoutWhoWarning}, LintV2(policy, true))
+	assert.Contains(t, orgWideWithoutWhoWarning, "allows no one")
+
+	decision := EvaluateV2([]NamedPolicy{{Name: "org-wide", PolicyData: policy}}, EvaluationRequest{
+		Email:      "johndoe@example.com",
+		SocketType: SocketTypeHTTP,
+	})
+	assert.False(t, decision.Allowed)
+}
+
+func TestLintV2_NetworkRestrictedByCountry(t *testing.T) {
+	warnings := LintV2(border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{Network: &border0client.NetworkPermissions{}},
+		Condition: border0client.PolicyConditionV2{
+			Where: border0client.PolicyWhere{CountryNot: []string{"RU"}},
+		},
+	}, false)
+
+	assert.Empty(t, warnings)
+}
+
+func TestLintV1(t *testing.T) {
+	assert.Empty(t, LintV1(border0client.PolicyData{}, false))
+	assert.Equal(t, []string{orgWideWithoutWhoWarning}, LintV1(border0client.PolicyData{}, true))
+
+	withDomain := border0client.PolicyData{}
+	withDomain.Condition.Who.Domain = []string{"example.com"}
+	assert.Empty(t, LintV1(withDomain, true))
+}
@@ -0,0 +1,248 @@
+package policyutil
+
+import (
+	border0client "github.com/borderzero/border0-go/client"
+)
+
+// MergeV2 merges the given v2 policy documents in order, each document
+// layered on top of the result of merging the ones before it.
+//
+// The merge rules are:
+//   - a permission is allowed if it is allowed in any document
+//   - allowed lists (databases, query types, commands, connections, namespaces,
+//     containers, usernames) are unioned, where an unset list means "allow all"
+//     and therefore absorbs any restricted list it is merged with
+//   - the where allow lists (allowed_ip, country) are unioned the same way, an unset
+//     list allows from anywhere and absorbs any restricted list it is merged with
+//   - the who lists and the country_not deny list are unioned, for country_not this
+//     means that a country denied by any document is denied by the merged document,
+//     so merging can narrow where access is allowed
+//   - scalars (max_session_duration_seconds, pod_selector and the when times)
+//     are taken from the last document that sets them
+func MergeV2(docs ...border0client.PolicyDataV2) border0client.PolicyDataV2 {
+	if len(docs) == 0 {
+		return border0client.PolicyDataV2{}
+	}
+	// merging onto an empty document would make the first document's where allow lists
+	// unrestricted, so the merge starts from the first document instead
+	merged := docs[0]
+	for _, doc := range docs[1:] {
+		merged = mergeV2(merged, doc)
+	}
+	return merged
+}
+
+func mergeV2(base, overlay border0client.PolicyDataV2) border0client.PolicyDataV2 {
+	return border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Database:   mergeDatabasePermissions(base.Permissions.Database, overlay.Permissions.Database),
+			SSH:        mergeSSHPermissions(base.Permissions.SSH, overlay.Permissions.SSH),
+			HTTP:       mergeEmpty(base.Permissions.HTTP, overlay.Permissions.HTTP),
+			Kubernetes: mergeEmpty(base.Permissions.Kubernetes, overlay.Permissions.Kubernetes),
+			TLS:        mergeEmpty(base.Permissions.TLS, overlay.Permissions.TLS),
+			VNC:        mergeEmpty(base.Permissions.VNC, overlay.Permissions.VNC),
+			RDP:        mergeEmpty(base.Permissions.RDP, overlay.Permissions.RDP),
+			Network:    mergeEmpty(base.Permissions.Network, overlay.Permissions.Network),
+		},
+		Condition: border0client.PolicyConditionV2{
+			Who: border0client.PolicyWhoV2{
+				Email:          unionStrings(base.Condition.Who.Email, overlay.Condition.Who.Email),
+				Group:          unionStrings(base.Condition.Who.Group, overlay.Condition.Who.Group),
+				ServiceAccount: unionStrings(base.Condition.Who.ServiceAccount, overlay.Condition.Who.ServiceAccount),
+			},
+			Where: border0client.PolicyWhere{
+				AllowedIP:  unionWhereAllowList(base.Condition.Where.AllowedIP, overlay.Condition.Where.AllowedIP),
+				Country:    unionWhereAllowList(base.Condition.Where.Country, overlay.Condition.Where.Country),
+				CountryNot: unionStrings(base.Condition.Where.CountryNot, overlay.Condition.Where.CountryNot),
+			},
+			When: border0client.PolicyWhen{
+				After:           lastString(base.Condition.When.After, overlay.Condition.When.After),
+				Before:          lastString(base.Condition.When.Before, overlay.Condition.When.Before),
+				TimeOfDayAfter:  lastString(base.Condition.When.TimeOfDayAfter, overlay.Condition.When.TimeOfDayAfter),
+				TimeOfDayBefore: lastString(base.Condition.When.TimeOfDayBefore, overlay.Condition.When.TimeOfDayBefore),
+			},
+		},
+	}
+}
+
+func mergeDatabasePermissions(base, overlay *border0client.DatabasePermissions) *border0client.DatabasePermissions {
+	if base == nil || overlay == nil {
+		return firstNonNil(overlay, base)
+	}
+	return &border0client.DatabasePermissions{
+		AllowedDatabases:          mergeKeyedList(base.AllowedDatabases, overlay.AllowedDatabases, databaseKey, mergeDatabasePermission),
+		MaxSessionDurationSeconds: lastInt(base.MaxSessionDurationSeconds, overlay.MaxSessionDurationSeconds),
+	}
+}
+
+func mergeDatabasePermission(base, overlay border0client.DatabasePermission) border0client.DatabasePermission {
+	return border0client.DatabasePermission{
+		Database:          base.Database,
+		AllowedQueryTypes: unionAllowList(base.AllowedQueryTypes, overlay.AllowedQueryTypes),
+	}
+}
+
+func mergeSSHPermissions(base, overlay *border0client.SSHPermissions) *border0client.SSHPermissions {
+	if base == nil || overlay == nil {
+		return firstNonNil(overlay, base)
+	}
+	return &border0client.SSHPermissions{
+		Shell:                     mergeEmpty(base.Shell, overlay.Shell),
+		Exec:                      mergeSSHExecPermission(base.Exec, overlay.Exec),
+		SFTP:                      mergeEmpty(base.SFTP, overlay.SFTP),
+		TCPForwarding:             mergeSSHTCPForwardingPermission(base.TCPForwarding, overlay.TCPForwarding),
+		KubectlExec:               mergeSSHKubectlExecPermission(base.KubectlExec, overlay.KubectlExec),
+		DockerExec:                mergeSSHDockerExecPermission(base.DockerExec, overlay.DockerExec),
+		MaxSessionDurationSeconds: lastInt(base.MaxSessionDurationSeconds, overlay.MaxSessionDurationSeconds),
+		AllowedUsernames:          unionAllowList(base.AllowedUsernames, overlay.AllowedUsernames),
+	}
+}
+
+func mergeSSHExecPermission(base, overlay *border0client.SSHExecPermission) *border0client.SSHExecPermission {
+	if base == nil || overlay == nil {
+		return firstNonNil(overlay, base)
+	}
+	return &border0client.SSHExecPermission{
+		Commands: unionAllowList(base.Commands, overlay.Commands),
+	}
+}
+
+func mergeSSHTCPForwardingPermission(base, overlay *border0client.SSHTCPForwardingPermission) *border0client.SSHTCPForwardingPermission {
+	if base == nil || overlay == nil {
+		return firstNonNil(overlay, base)
+	}
+	return &border0client.SSHTCPForwardingPermission{
+		AllowedConnections: mergeKeyedList(base.AllowedConnections, overlay.AllowedConnections, connectionKey, func(base, _ border0client.SSHTcpForwardingConnection) border0client.SSHTcpForwardingConnection {
+			return base
+		}),
+	}
+}
+
+func mergeSSHKubectlExecPermission(base, overlay *border0client.SSHKubectlExecPermission) *border0client.SSHKubectlExecPermission {
+	if base == nil || overlay == nil {
+		return firstNonNil(overlay, base)
+	}
+	return &border0client.SSHKubectlExecPermission{
+		AllowedNamespaces: mergeKeyedList(base.AllowedNamespaces, overlay.AllowedNamespaces, namespaceKey, mergeKubectlExecNamespace),
+	}
+}
+
+func mergeKubectlExecNamespace(base, overlay border0client.KubectlExecNamespace) border0client.KubectlExecNamespace {
+	// an unset pod selector allows every pod in the namespace, so it wins over any selector,
+	// two different selectors can not be unioned so the overlay's selector is used instead
+	podSelector := overlay.PodSelector
+	if base.PodSelector == nil {
+		podSelector = nil
+	}
+	return border0client.KubectlExecNamespace{
+		Namespace:   base.Namespace,
+		PodSelector: podSelector,
+	}
+}
+
+func mergeSSHDockerExecPermission(base, overlay *border0client.SSHDockerExecPermission) *border0client.SSHDockerExecPermission {
+	if base == nil || overlay == nil {
+		return firstNonNil(overlay, base)
+	}
+	return &border0client.SSHDockerExecPermission{
+		AllowedContainers: unionAllowList(base.AllowedContainers, overlay.AllowedContainers),
+	}
+}
+
+func databaseKey(db border0client.DatabasePermission) string { return db.Database }
+
+func namespaceKey(ns border0client.KubectlExecNamespace) string { return ns.Namespace }
+
+func connectionKey(conn border0client.SSHTcpForwardingConnection) string {
+	var address, port string
+	if conn.DestinationAddress != nil {
+		address = *conn.DestinationAddress
+	}
+	if conn.DestinationPort != nil {
+		port = *conn.DestinationPort
+	}
+	return address + ":" + port
+}
+
+// mergeEmpty merges permissions that carry no settings, they are allowed if either side allows them.
+func mergeEmpty[T any](base, overlay *T) *T {
+	return firstNonNil(overlay, base)
+}
+
+func firstNonNil[T any](values ...*T) *T {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// unionStrings unions two condition lists, preserving the order of first appearance.
+// The result is only nil when both lists are nil.
+func unionStrings(base, overlay []string) []string {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	union := make([]string, 0, len(base)+len(overlay))
+	seen := make(map[string]bool, len(base)+len(overlay))
+	for _, v := range append(append([]string{}, base...), overlay...) {
+		if !seen[v] {
+			seen[v] = true
+			union = append(union, v)
+		}
+	}
+	return union
+}
+
+// unionAllowList unions two allowed lists, where a nil list means everything is allowed.
+func unionAllowList(base, overlay *[]string) *[]string {
+	if base == nil || overlay == nil {
+		return nil
+	}
+	union := unionStrings(*base, *overlay)
+	return &union
+}
+
+// unionWhereAllowList unions two where allow lists, where an empty list allows from anywhere.
+// The result is only nil when both lists are, like unionStrings.
+func unionWhereAllowList(base, overlay []string) []string {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	if len(base) == 0 || len(overlay) == 0 {
+		return []string{}
+	}
+	return unionStrings(base, overlay)
+}
+
+// mergeKeyedList unions two allowed lists of structured entries, entries with the same
+// key are combined with mergeFn. A nil list means everything is allowed.
+func mergeKeyedList[T any](base, overlay *[]T, keyFn func(T) string, mergeFn func(base, overlay T) T) *[]T {
+	if base == nil || overlay == nil {
+		return nil
+	}
+	merged := make([]T, 0, len(*base)+len(*overlay))
+	index := make(map[string]int, len(*base)+len(*overlay))
+	for _, entry := range append(append([]T{}, *base...), *overlay...) {
+		key := keyFn(entry)
+		if i, ok := index[key]; ok {
+			merged[i] = mergeFn(merged[i], entry)
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, entry)
+	}
+	return &merged
+}
+
+func lastInt(base, overlay *int) *int {
+	return firstNonNil(overlay, base)
+}
+
+func lastString(base, overlay string) string {
+	if overlay != "" {
+		return overlay
+	}
+	return base
+}
@@ -0,0 +1,264 @@
+package policyutil
+
+import (
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestMergeV2_SingleDocumentIsUnchanged(t *testing.T) {
+	doc := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			SSH: &border0client.SSHPermissions{
+				Shell:                     &border0client.SSHShellPermission{},
+				MaxSessionDurationSeconds: ptr(3600),
+				AllowedUsernames:          &[]string{"ubuntu"},
+			},
+			HTTP: &border0client.HTTPPermissions{},
+		},
+		Condition: border0client.PolicyConditionV2{
+			Who:   border0client.PolicyWhoV2{Email: []string{"johndoe@example.com"}, Group: []string{}, ServiceAccount: []string{}},
+			Where: border0client.PolicyWhere{AllowedIP: []string{"0.0.0.0/0"}},
+			When:  border0client.PolicyWhen{TimeOfDayAfter: "00:00 UTC"},
+		},
+	}
+
+	assert.Equal(t, doc, MergeV2(doc))
+}
+
+func TestMergeV2_PermissionsAreAllowedIfAllowedAnywhere(t *testing.T) {
+	base := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			HTTP: &border0client.HTTPPermissions{},
+			SSH:  &border0client.SSHPermissions{Shell: &border0client.SSHShellPermission{}},
+		},
+	}
+	overlay := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Network: &border0client.NetworkPermissions{},
+			SSH:     &border0client.SSHPermissions{SFTP: &border0client.SSHSFTPPermission{}},
+		},
+	}
+
+	merged := MergeV2(base, overlay)
+
+	assert.NotNil(t, merged.Permissions.HTTP)
+	assert.NotNil(t, merged.Permissions.Network)
+	assert.Nil(t, merged.Permissions.Database)
+	assert.Nil(t, merged.Permissions.TLS)
+	assert.Equal(t, &border0client.SSHPermissions{
+		Shell: &border0client.SSHShellPermission{},
+		SFTP:  &border0client.SSHSFTPPermission{},
+	}, merged.Permissions.SSH)
+}
+
+func TestMergeV2_AllowedListsAreUnioned(t *testing.T) {
+	base := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Database: &border0client.DatabasePermissions{
+				AllowedDatabases: &[]border0client.DatabasePermission{
+					{Database: "books", AllowedQueryTypes: &[]string{"ReadOnly"}},
+					{Database: "users", AllowedQueryTypes: &[]string{"ReadOnly"}},
+				},
+			},
+			SSH: &border0client.SSHPermissions{
+				Exec:             &border0client.SSHExecPermission{Commands: &[]string{"ls", "whoami"}},
+				DockerExec:       &border0client.SSHDockerExecPermission{AllowedContainers: &[]string{"web"}},
+				AllowedUsernames: &[]string{"ubuntu"},
+				TCPForwarding: &border0client.SSHTCPForwardingPermission{
+					AllowedConnections: &[]border0client.SSHTcpForwardingConnection{
+						{DestinationAddress: ptr("db.internal"), DestinationPort: ptr("5432")},
+					},
+				},
+			},
+		},
+	}
+	overlay := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Database: &border0client.DatabasePermissions{
+				AllowedDatabases: &[]border0client.DatabasePermission{
+					{Database: "books", AllowedQueryTypes: &[]string{"ReadWrite"}},
+					{Database: "orders"},
+				},
+			},
+			SSH: &border0client.SSHPermissions{
+				Exec:             &border0client.SSHExecPermission{Commands: &[]string{"whoami", "uptime"}},
+				DockerExec:       &border0client.SSHDockerExecPermission{AllowedContainers: &[]string{"worker"}},
+				AllowedUsernames: &[]string{"ec2-user", "ubuntu"},
+				TCPForwarding: &border0client.SSHTCPForwardingPermission{
+					AllowedConnections: &[]border0client.SSHTcpForwardingConnection{
+						{DestinationAddress: ptr("db.internal"), DestinationPort: ptr("5432")},
+						{DestinationAddress: ptr("cache.internal"), DestinationPort: ptr("6379")},
+					},
+				},
+			},
+		},
+	}
+
+	merged := MergeV2(base, overlay)
+
+	assert.Equal(t, &[]border0client.DatabasePermission{
+		{Database: "books", AllowedQueryTypes: &[]string{"ReadOnly", "ReadWrite"}},
+		{Database: "users", AllowedQueryTypes: &[]string{"ReadOnly"}},
+		{Database: "orders"},
+	}, merged.Permissions.Database.AllowedDatabases)
+	assert.Equal(t, &[]string{"ls", "whoami", "uptime"}, merged.Permissions.SSH.Exec.Commands)
+	assert.Equal(t, &[]string{"web", "worker"}, merged.Permissions.SSH.DockerExec.AllowedContainers)
+	assert.Equal(t, &[]string{"ubuntu", "ec2-user"}, merged.Permissions.SSH.AllowedUsernames)
+	assert.Equal(t, &[]border0client.SSHTcpForwardingConnection{
+		{DestinationAddress: ptr("db.internal"), DestinationPort: ptr("5432")},
+		{DestinationAddress: ptr("cache.internal"), DestinationPort: ptr("6379")},
+	}, merged.Permissions.SSH.TCPForwarding.AllowedConnections)
+}
+
+func TestMergeV2_UnrestrictedListAbsorbsRestrictedList(t *testing.T) {
+	base := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			SSH: &border0client.SSHPermissions{
+				Exec:             &border0client.SSHExecPermission{Commands: &[]string{"ls"}},
+				AllowedUsernames: &[]string{"ubuntu"},
+				KubectlExec: &border0client.SSHKubectlExecPermission{
+					AllowedNamespaces: &[]border0client.KubectlExecNamespace{
+						{Namespace: "default", PodSelector: &map[string]string{"app": "web"}},
+					},
+				},
+			},
+		},
+	}
+	overlay := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			SSH: &border0client.SSHPermissions{
+				Exec: &border0client.SSHExecPermission{},
+				KubectlExec: &border0client.SSHKubectlExecPermission{
+					AllowedNamespaces: &[]border0client.KubectlExecNamespace{
+						{Namespace: "default"},
+					},
+				},
+			},
+		},
+	}
+
+	merged := MergeV2(base, overlay)
+
+	assert.Nil(t, merged.Permissions.SSH.Exec.Commands)
+	assert.Nil(t, merged.Permissions.SSH.AllowedUsernames)
+	assert.Equal(t, &[]border0client.KubectlExecNamespace{
+		{Namespace: "default"},
+	}, merged.Permissions.SSH.KubectlExec.AllowedNamespaces)
+}
+
+func TestMergeV2_LastDocumentWinsOnScalars(t *testing.T) {
+	base := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			Database: &border0client.DatabasePermissions{MaxSessionDurationSeconds: ptr(3600)},
+			SSH:      &border0client.SSHPermissions{MaxSessionDurationSeconds: ptr(3600)},
+		},
+		Condition: border0client.PolicyConditionV2{
+			When: border0client.PolicyWhen{
+				After:           "2024-01-01T00:00:00Z",
+				TimeOfDayAfter:  "08:00 UTC",
+				TimeOfDayBefore: "18:00 UTC",
+			},
+		},
+	}
+	overlay := border0client.PolicyDataV2{
+		Permissions: border0client.PolicyPermissions{
+			SSH: &border0client.SSHPermissions{MaxSessionDurationSeconds: ptr(600)},
+		},
+		Condition: border0client.PolicyConditionV2{
+			When: border0client.PolicyWhen{
+				TimeOfDayBefore: "20:00 UTC",
+			},
+		},
+	}
+
+	merged := MergeV2(base, overlay)
+
+	assert.Equal(t, ptr(3600), merged.Permissions.Database.MaxSessionDurationSeconds)
+	assert.Equal(t, ptr(600), merged.Permissions.SSH.MaxSessionDurationSeconds)
+	assert.Equal(t, border0client.PolicyWhen{
+		After:           "2024-01-01T00:00:00Z",
+		TimeOfDayAfter:  "08:00 UTC",
+		TimeOfDayBefore: "20:00 UTC",
+	}, merged.Condition.When)
+}
+
+func TestMergeV2_ConditionListsAreUnioned(t *testing.T) {
+	first := border0client.PolicyDataV2{
+		Condition: border0client.PolicyConditionV2{
+			Who:   border0client.PolicyWhoV2{Email: []string{"alice@example.com"}, Group: []string{}},
+			Where: border0client.PolicyWhere{AllowedIP: []string{"10.0.0.0/8"}, Country: []string{"NL"}, CountryNot: []string{"BE"}},
+		},
+	}
+	second := border0client.PolicyDataV2{
+		Condition: border0client.PolicyConditionV2{
+			Who:   border0client.PolicyWhoV2{Email: []string{"bob@example.com", "alice@example.com"}, ServiceAccount: []string{"ci"}},
+			Where: border0client.PolicyWhere{AllowedIP: []string{"10.0.0.0/8"}, Country: []string{"DE"}},
+		},
+	}
+	third := border0client.PolicyDataV2{
+		Condition: border0client.PolicyConditionV2{
+			Where: border0client.PolicyWhere{AllowedIP: []string{"192.168.0.0/16"}, Country: []string{"NL"}, CountryNot: []string{"FR"}},
+		},
+	}
+
+	merged := MergeV2(first, second, third)
+
+	assert.Equal(t, border0client.PolicyWhoV2{
+		Email:          []string{"alice@example.com", "bob@example.com"},
+		Group:          []string{},
+		ServiceAccount: []string{"ci"},
+	}, merged.Condition.Who)
+	assert.Equal(t, border0client.PolicyWhere{
+		AllowedIP:  []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Country:    []string{"NL", "DE"},
+		CountryNot: []string{"BE", "FR"},
+	}, merged.Condition.Where)
+}
+
+func TestMergeV2_UnsetWhereAllowListAbsorbsRestrictedList(t *testing.T) {
+	restricted := border0client.PolicyDataV2{
+		Condition: border0client.PolicyConditionV2{
+			Where: border0client.PolicyWhere{AllowedIP: []string{"10.0.0.0/8"}, Country: []string{"NL"}},
+		},
+	}
+	anywhere := border0client.PolicyDataV2{
+		Condition: border0client.PolicyConditionV2{
+			Where: border0client.PolicyWhere{AllowedIP: []string{}},
+		},
+	}
+
+	// a document without allowed_ip or country allows from anywhere, and so does the merged document
+	for _, merged := range []border0client.PolicyDataV2{MergeV2(restricted, anywhere), MergeV2(anywhere, restricted)} {
+		assert.Equal(t, []string{}, merged.Condition.Where.AllowedIP)
+		assert.Equal(t, []string{}, merged.Condition.Where.Country)
+	}
+	assert.Equal(t, restricted.Condition.Where, MergeV2(restricted, restricted).Condition.Where)
+}
+
+func TestMergeV2_CountryNotDenyListsAreUnioned(t *testing.T) {
+	// unlike the allow lists, the union of deny lists is the more restrictive result
+	first := border0client.PolicyDataV2{
+		Condition: border0client.PolicyConditionV2{
+			Where: border0client.PolicyWhere{CountryNot: []string{"BE", "RU"}},
+		},
+	}
+	second := border0client.PolicyDataV2{
+		Condition: border0client.PolicyConditionV2{
+			Where: border0client.PolicyWhere{CountryNot: []string{"RU"}},
+		},
+	}
+	unrestricted := border0client.PolicyDataV2{}
+
+	assert.Equal(t, []string{"BE", "RU"}, MergeV2(first, second).Condition.Where.CountryNot)
+	assert.Equal(t, []string{"RU", "BE"}, MergeV2(second, first).Condition.Where.CountryNot)
+	// a document without a deny list doesn't lift the countries denied by the others
+	assert.Equal(t, []string{"BE", "RU"}, MergeV2(first, unrestricted).Condition.Where.CountryNot)
+}
+
+func TestMergeV2_NoDocuments(t *testing.T) {
+	assert.Equal(t, border0client.PolicyDataV2{}, MergeV2())
+}
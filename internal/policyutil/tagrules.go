@@ -0,0 +1,159 @@
+package policyutil
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Tag rule operators. The Border0 API matches sockets against a list of tag maps, a socket matches
+// when all the tags of any one map are set on it with the same values, so only operators that can be
+// expressed as exact values are supported.
+const (
+	TagRuleOperatorEquals = "equals"
+	TagRuleOperatorAnyOf  = "any_of"
+)
+
+// TagRuleOperators is the list of supported tag rule operators.
+var TagRuleOperators = []string{
+	TagRuleOperatorEquals,
+	TagRuleOperatorAnyOf,
+}
+
+// MaxTagMaps is the maximum number of tag maps that tag rules can expand into, so a few any_of rules
+// with many values don't turn into an unreasonably large policy.
+const MaxTagMaps = 256
+
+// TagRule is a condition on a single socket tag. A socket must satisfy all the tag rules of a policy.
+type TagRule struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// ExpandTagRules translates tag rules into the tag maps of the Border0 API. Rules with the any_of
+// operator expand into one map per value, so the result is the cross product of the rules' values,
+// which must not have more than MaxTagMaps maps.
+func ExpandTagRules(rules []TagRule) ([]map[string]string, error) {
+	if len(rules) == 0 {
+		return []map[string]string{}, nil
+	}
+
+	rules = slices.Clone(rules)
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Key < rules[j].Key })
+
+	tagMaps := []map[string]string{{}}
+	for i, rule := range rules {
+		if i > 0 && rules[i-1].Key == rule.Key {
+			return nil, fmt.Errorf("tag key %q is used by more than one tag rule, use the any_of operator to match several values", rule.Key)
+		}
+		switch rule.Operator {
+		case TagRuleOperatorEquals:
+			if len(rule.Values) != 1 {
+				return nil, fmt.Errorf("tag rule for key %q with the equals operator must have exactly one value, got %d", rule.Key, len(rule.Values))
+			}
+		case TagRuleOperatorAnyOf:
+			if len(rule.Values) == 0 {
+				return nil, fmt.Errorf("tag rule for key %q with the any_of operator must have at least one value", rule.Key)
+			}
+		default:
+			return nil, fmt.Errorf("tag rule for key %q has unsupported operator %q", rule.Key, rule.Operator)
+		}
+
+		if len(tagMaps)*len(rule.Values) > MaxTagMaps {
+			return nil, fmt.Errorf("tag rules expand into more than %d tag maps, the product of the number of values of all the rules must be at most %d", MaxTagMaps, MaxTagMaps)
+		}
+
+		values := slices.Clone(rule.Values)
+		sort.Strings(values)
+		expanded := make([]map[string]string, 0, len(tagMaps)*len(values))
+		for _, tagMap := range tagMaps {
+			for _, value := range values {
+				m := make(map[string]string, len(tagMap)+1)
+				for k, v := range tagMap {
+					m[k] = v
+				}
+				m[rule.Key] = value
+				expanded = append(expanded, m)
+			}
+		}
+		tagMaps = expanded
+	}
+	return tagMaps, nil
+}
+
+// CollapseTagRules translates the tag maps of the Border0 API back into tag rules. It returns false
+// when the tag maps are not the cross product of per key values, and can't be expressed as tag rules.
+func CollapseTagRules(tagMaps []map[string]string) ([]TagRule, bool) {
+	if len(tagMaps) == 0 {
+		return nil, true
+	}
+
+	var keys []string
+	for key := range tagMaps[0] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	valuesByKey := map[string][]string{}
+	unique := map[string]bool{}
+	for _, tagMap := range tagMaps {
+		if len(tagMap) != len(keys) || len(tagMap) == 0 {
+			return nil, false
+		}
+		for _, key := range keys {
+			value, ok := tagMap[key]
+			if !ok {
+				return nil, false
+			}
+			if !slices.Contains(valuesByKey[key], value) {
+				valuesByKey[key] = append(valuesByKey[key], value)
+			}
+		}
+		unique[tagMapKey(tagMap)] = true
+	}
+
+	combinations := 1
+	rules := make([]TagRule, 0, len(keys))
+	for _, key := range keys {
+		values := valuesByKey[key]
+		sort.Strings(values)
+		combinations *= len(values)
+
+		operator := TagRuleOperatorEquals
+		if len(values) > 1 {
+			operator = TagRuleOperatorAnyOf
+		}
+		rules = append(rules, TagRule{Key: key, Operator: operator, Values: values})
+	}
+	if combinations != len(unique) {
+		return nil, false
+	}
+	return rules, true
+}
+
+// EqualTagRules reports whether the tag maps match the same sockets, regardless of their order
+// and of duplicate maps.
+func EqualTagRules(a, b []map[string]string) bool {
+	return slices.Equal(tagMapKeys(a), tagMapKeys(b))
+}
+
+func tagMapKeys(tagMaps []map[string]string) []string {
+	keys := make([]string, 0, len(tagMaps))
+	for _, tagMap := range tagMaps {
+		keys = append(keys, tagMapKey(tagMap))
+	}
+	sort.Strings(keys)
+	return slices.Compact(keys)
+}
+
+// tagMapKey returns a canonical representation of the tag map, with its tags sorted by key.
+func tagMapKey(tagMap map[string]string) string {
+	tags := make([]string, 0, len(tagMap))
+	for key, value := range tagMap {
+		tags = append(tags, fmt.Sprintf("%q=%q", key, value))
+	}
+	sort.Strings(tags)
+	return strings.Join(tags, ",")
+}
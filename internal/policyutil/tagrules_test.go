@@ -0,0 +1,132 @@
+package policyutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandTagRules(t *testing.T) {
+	tagMaps, err := ExpandTagRules([]TagRule{
+		{Key: "team", Operator: TagRuleOperatorEquals, Values: []string{"backend"}},
+		{Key: "env", Operator: TagRuleOperatorAnyOf, Values: []string{"staging", "prod"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"env": "prod", "team": "backend"},
+		{"env": "staging", "team": "backend"},
+	}, tagMaps)
+
+	tagMaps, err = ExpandTagRules(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{}, tagMaps)
+}
+
+func TestExpandTagRulesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []TagRule
+		err   string
+	}{
+		{
+			name: "duplicate key",
+			rules: []TagRule{
+				{Key: "env", Operator: TagRuleOperatorEquals, Values: []string{"prod"}},
+				{Key: "env", Operator: TagRuleOperatorEquals, Values: []string{"staging"}},
+			},
+			err: `tag key "env" is used by more than one tag rule`,
+		},
+		{
+			name:  "equals with several values",
+			rules: []TagRule{{Key: "env", Operator: TagRuleOperatorEquals, Values: []string{"prod", "staging"}}},
+			err:   `tag rule for key "env" with the equals operator must have exactly one value, got 2`,
+		},
+		{
+			name:  "any_of without values",
+			rules: []TagRule{{Key: "env", Operator: TagRuleOperatorAnyOf}},
+			err:   `tag rule for key "env" with the any_of operator must have at least one value`,
+		},
+		{
+			name: "too many tag maps",
+			rules: []TagRule{
+				{Key: "env", Operator: TagRuleOperatorAnyOf, Values: manyValues(16)},
+				{Key: "region", Operator: TagRuleOperatorAnyOf, Values: manyValues(16)},
+				{Key: "team", Operator: TagRuleOperatorAnyOf, Values: manyValues(2)},
+			},
+			err: `tag rules expand into more than 256 tag maps`,
+		},
+		{
+			name:  "unsupported operator",
+			rules: []TagRule{{Key: "env", Operator: "exists"}},
+			err:   `tag rule for key "env" has unsupported operator "exists"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ExpandTagRules(test.rules)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestExpandTagRulesLimit(t *testing.T) {
+	tagMaps, err := ExpandTagRules([]TagRule{
+		{Key: "env", Operator: TagRuleOperatorAnyOf, Values: manyValues(16)},
+		{Key: "region", Operator: TagRuleOperatorAnyOf, Values: manyValues(16)},
+	})
+	require.NoError(t, err)
+	assert.Len(t, tagMaps, MaxTagMaps)
+}
+
+func manyValues(n int) []string {
+	values := make([]string, 0, n)
+	for i := range n {
+		values = append(values, fmt.Sprintf("value-%d", i))
+	}
+	return values
+}
+
+func TestCollapseTagRules(t *testing.T) {
+	rules, ok := CollapseTagRules([]map[string]string{
+		{"team": "backend", "env": "staging"},
+		{"env": "prod", "team": "backend"},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, []TagRule{
+		{Key: "env", Operator: TagRuleOperatorAnyOf, Values: []string{"prod", "staging"}},
+		{Key: "team", Operator: TagRuleOperatorEquals, Values: []string{"backend"}},
+	}, rules)
+
+	// not a cross product: (team=backend AND env=prod) OR (team=frontend AND env=staging)
+	_, ok = CollapseTagRules([]map[string]string{
+		{"team": "backend", "env": "prod"},
+		{"team": "frontend", "env": "staging"},
+	})
+	assert.False(t, ok)
+
+	// maps with different keys
+	_, ok = CollapseTagRules([]map[string]string{
+		{"team": "backend"},
+		{"env": "staging"},
+	})
+	assert.False(t, ok)
+
+	rules, ok = CollapseTagRules(nil)
+	assert.True(t, ok)
+	assert.Empty(t, rules)
+}
+
+func TestEqualTagRules(t *testing.T) {
+	assert.True(t, EqualTagRules(
+		[]map[string]string{{"team": "backend"}, {"env": "staging", "team": "frontend"}},
+		[]map[string]string{{"team": "frontend", "env": "staging"}, {"team": "backend"}},
+	))
+	assert.True(t, EqualTagRules(nil, []map[string]string{}))
+	assert.False(t, EqualTagRules(
+		[]map[string]string{{"team": "backend"}},
+		[]map[string]string{{"team": "backend", "env": "staging"}},
+	))
+}
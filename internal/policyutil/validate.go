@@ -0,0 +1,106 @@
+package policyutil
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	border0client "github.com/borderzero/border0-go/client"
+)
+
+// timeOfDayPattern matches a 24-hour "HH:MM" time, optionally followed by a time zone e.g. "08:30 UTC".
+var timeOfDayPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]( [A-Za-z][A-Za-z0-9_+\-/]*)?$`)
+
+// countryCodePattern matches the shape of an ISO 3166-1 alpha-2 country code, two upper-case letters e.g. "NL".
+// Codes are not checked against a list of countries, which would reject codes the API accepts such as "XK".
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// ValidateTimestamp checks that the given value is an RFC 3339 timestamp e.g. "2024-01-31T08:00:00Z".
+func ValidateTimestamp(value string) error {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return fmt.Errorf("%q is not a valid RFC 3339 timestamp e.g. 2024-01-31T08:00:00Z", value)
+	}
+	return nil
+}
+
+// ValidateTimeOfDay checks that the given value is a 24-hour "HH:MM" time of day,
+// optionally followed by a time zone e.g. "08:30 UTC".
+func ValidateTimeOfDay(value string) error {
+	if !timeOfDayPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid time of day, expected HH:MM optionally followed by a time zone e.g. 08:30 UTC", value)
+	}
+	return nil
+}
+
+// ValidateIPOrCIDR checks that the given value is an IPv4/IPv6 address or CIDR block.
+func ValidateIPOrCIDR(value string) error {
+	if _, err := netip.ParsePrefix(value); err == nil {
+		return nil
+	}
+	if _, err := netip.ParseAddr(value); err == nil {
+		return nil
+	}
+	return fmt.Errorf("%q is not a valid IP address or CIDR block e.g. 10.0.0.0/8", value)
+}
+
+// ValidateCountryCode checks that the given value has the shape of an ISO 3166-1 alpha-2 country code e.g. "NL".
+func ValidateCountryCode(value string) error {
+	if !countryCodePattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid ISO 3166-1 alpha-2 country code, expected two upper-case letters e.g. NL", value)
+	}
+	return nil
+}
+
+// ValidateCondition checks the where and when parts of a policy condition, which are shared by v1 and
+// v2 policies. It returns every malformed value, as well as contradictions between values: an after
+// timestamp that is not before the before timestamp, and a country that is both allowed and denied.
+func ValidateCondition(where border0client.PolicyWhere, when border0client.PolicyWhen) []error {
+	var errs []error
+	check := func(attribute string, validate func(string) error, values ...string) {
+		for _, value := range values {
+			if err := validate(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", attribute, err))
+			}
+		}
+	}
+
+	check("where.allowed_ip", ValidateIPOrCIDR, where.AllowedIP...)
+	check("where.country", ValidateCountryCode, where.Country...)
+	check("where.country_not", ValidateCountryCode, where.CountryNot...)
+
+	denied := make(map[string]bool, len(where.CountryNot))
+	for _, country := range where.CountryNot {
+		denied[strings.ToUpper(country)] = true
+	}
+	for _, country := range where.Country {
+		if denied[strings.ToUpper(country)] {
+			errs = append(errs, fmt.Errorf("where: country %q is listed in both country and country_not", country))
+		}
+	}
+
+	if when.After != "" {
+		check("when.after", ValidateTimestamp, when.After)
+	}
+	if when.Before != "" {
+		check("when.before", ValidateTimestamp, when.Before)
+	}
+	if when.TimeOfDayAfter != "" {
+		check("when.time_of_day_after", ValidateTimeOfDay, when.TimeOfDayAfter)
+	}
+	if when.TimeOfDayBefore != "" {
+		check("when.time_of_day_before", ValidateTimeOfDay, when.TimeOfDayBefore)
+	}
+
+	after, afterErr := time.Parse(time.RFC3339, when.After)
+	before, beforeErr := time.Parse(time.RFC3339, when.Before)
+	if afterErr == nil && beforeErr == nil && !after.Before(before) {
+		errs = append(errs, fmt.Errorf("when: after (%s) must be earlier than before (%s), otherwise the policy never applies", when.After, when.Before))
+	}
+
+	// NOTE: time_of_day_after may be later than time_of_day_before, which is
+	// a window that wraps around midnight e.g. 22:00 UTC to 06:00 UTC.
+
+	return errs
+}
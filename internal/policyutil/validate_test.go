@@ -0,0 +1,112 @@
+package policyutil
+
+import (
+	"testing"
+
+	border0client "github.com/borderzero/border0-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTimestamp(t *testing.T) {
+	assert.NoError(t, ValidateTimestamp("2022-10-13T05:12:26Z"))
+	assert.NoError(t, ValidateTimestamp("2022-10-13T05:12:26+02:00"))
+	assert.Error(t, ValidateTimestamp("2022-10-13"))
+	assert.Error(t, ValidateTimestamp("2022-13-13T05:12:26Z"))
+	assert.Error(t, ValidateTimestamp("yesterday"))
+}
+
+func TestValidateTimeOfDay(t *testing.T) {
+	assert.NoError(t, ValidateTimeOfDay("00:00 UTC"))
+	assert.NoError(t, ValidateTimeOfDay("23:59 UTC"))
+	assert.NoError(t, ValidateTimeOfDay("08:30"))
+	assert.NoError(t, ValidateTimeOfDay("08:30 Europe/Amsterdam"))
+	assert.Error(t, ValidateTimeOfDay("24:00 UTC"))
+	assert.Error(t, ValidateTimeOfDay("8:30 UTC"))
+	assert.Error(t, ValidateTimeOfDay("08:60 UTC"))
+	assert.Error(t, ValidateTimeOfDay("08:30UTC"))
+	assert.Error(t, ValidateTimeOfDay("8am"))
+}
+
+func TestValidateIPOrCIDR(t *testing.T) {
+	assert.NoError(t, ValidateIPOrCIDR("0.0.0.0/0"))
+	assert.NoError(t, ValidateIPOrCIDR("::/0"))
+	assert.NoError(t, ValidateIPOrCIDR("10.0.0.0/8"))
+	assert.NoError(t, ValidateIPOrCIDR("192.168.1.10"))
+	assert.NoError(t, ValidateIPOrCIDR("2001:db8::1"))
+	assert.Error(t, ValidateIPOrCIDR("10.0.0.0/33"))
+	assert.Error(t, ValidateIPOrCIDR("10.0.0.256"))
+	assert.Error(t, ValidateIPOrCIDR("example.com"))
+}
+
+func TestValidateCountryCode(t *testing.T) {
+	assert.NoError(t, ValidateCountryCode("NL"))
+	// user-assigned codes are accepted as well, e.g. XK for Kosovo
+	assert.NoError(t, ValidateCountryCode("XK"))
+	assert.Error(t, ValidateCountryCode("nl"))
+	assert.Error(t, ValidateCountryCode("NLD"))
+	assert.Error(t, ValidateCountryCode("N1"))
+	assert.Error(t, ValidateCountryCode(""))
+}
+
+func TestValidateCondition(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		errs := ValidateCondition(
+			border0client.PolicyWhere{
+				AllowedIP:  []string{"0.0.0.0/0", "::/0"},
+				Country:    []string{"NL", "CA", "US", "BR", "FR"},
+				CountryNot: []string{"BE"},
+			},
+			border0client.PolicyWhen{
+				After:           "2022-10-13T05:12:26Z",
+				Before:          "2023-10-13T05:12:26Z",
+				TimeOfDayAfter:  "22:00 UTC",
+				TimeOfDayBefore: "06:00 UTC",
+			},
+		)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, ValidateCondition(border0client.PolicyWhere{}, border0client.PolicyWhen{}))
+	})
+
+	t.Run("malformed values", func(t *testing.T) {
+		errs := ValidateCondition(
+			border0client.PolicyWhere{
+				AllowedIP:  []string{"10.0.0.0/33"},
+				Country:    []string{"Netherlands"},
+				CountryNot: []string{"xx"},
+			},
+			border0client.PolicyWhen{
+				After:           "2022-10-13",
+				Before:          "tomorrow",
+				TimeOfDayAfter:  "25:00 UTC",
+				TimeOfDayBefore: "9am",
+			},
+		)
+		assert.Len(t, errs, 7)
+		assert.ErrorContains(t, errs[0], "where.allowed_ip")
+		assert.ErrorContains(t, errs[1], "where.country")
+		assert.ErrorContains(t, errs[2], "where.country_not")
+		assert.ErrorContains(t, errs[3], "when.after")
+		assert.ErrorContains(t, errs[4], "when.before")
+		assert.ErrorContains(t, errs[5], "when.time_of_day_after")
+		assert.ErrorContains(t, errs[6], "when.time_of_day_before")
+	})
+
+	t.Run("contradictions", func(t *testing.T) {
+		errs := ValidateCondition(
+			border0client.PolicyWhere{
+				Country:    []string{"NL", "BE"},
+				CountryNot: []string{"BE"},
+			},
+			border0client.PolicyWhen{
+				After:  "2024-01-01T00:00:00Z",
+				Before: "2023-01-01T00:00:00Z",
+			},
+		)
+		assert.Len(t, errs, 2)
+		assert.ErrorContains(t, errs[0], `country "BE" is listed in both country and country_not`)
+		assert.ErrorContains(t, errs[1], "after (2024-01-01T00:00:00Z) must be earlier than before (2023-01-01T00:00:00Z)")
+	})
+}
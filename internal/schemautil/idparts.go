@@ -1,6 +1,9 @@
 package schemautil
 
 import (
+	"context"
+	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -8,32 +11,129 @@ import (
 )
 
 const (
-	idPartsDelimeter = ":"
+	idPartsDelimiter = ":"
 )
 
-// LoadMultipartID loads the schema resource id consisting of multiple parts onto the given variables.
-func LoadMultipartID(d *schema.ResourceData, parts ...*string) diag.Diagnostics {
-	idString := d.Id()
+// idPartEscaper escapes the delimiter in id parts, and the escape character itself, the same way
+// url paths are escaped, so ids of parts without either of them are unchanged.
+var idPartEscaper = strings.NewReplacer("%", "%25", idPartsDelimiter, "%3A")
+
+// IDCodec encodes and decodes the ids of resources that consist of multiple parts, e.g. the ids of
+// a parent resource and of a child resource. The parts are joined by a colon, and colons within a
+// part are escaped as %3A.
+type IDCodec struct {
+	names []string
+}
+
+// NewIDCodec returns an IDCodec for ids with the given parts. The names of the parts are only used
+// to describe the expected format in errors, and are usually the names of the resource's attributes.
+func NewIDCodec(names ...string) IDCodec {
+	return IDCodec{names: names}
+}
+
+// Format returns the expected format of the ids, e.g. policy_id:socket_id.
+func (c IDCodec) Format() string {
+	return strings.Join(c.names, idPartsDelimiter)
+}
+
+// Encode joins the parts into an id, escaping the delimiter within the parts.
+func (c IDCodec) Encode(parts ...string) string {
+	escaped := make([]string, 0, len(parts))
+	for _, part := range parts {
+		escaped = append(escaped, idPartEscaper.Replace(part))
+	}
+	return strings.Join(escaped, idPartsDelimiter)
+}
+
+// Decode splits the id into its parts. It returns an error describing the expected format when the
+// id doesn't have the expected number of parts, or any of them is empty or wrongly escaped.
+func (c IDCodec) Decode(id string) ([]string, error) {
+	escaped := strings.Split(id, idPartsDelimiter)
+	if len(escaped) != len(c.names) {
+		return nil, fmt.Errorf("invalid id %q, expected format is %s with %d parts separated by %q, got %d parts", id, c.Format(), len(c.names), idPartsDelimiter, len(escaped))
+	}
+
+	parts := make([]string, 0, len(escaped))
+	for i, part := range escaped {
+		if part == "" {
+			return nil, fmt.Errorf("invalid id %q, expected format is %s, %s must not be empty", id, c.Format(), c.names[i])
+		}
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q, expected format is %s, %s is not escaped correctly: %v", id, c.Format(), c.names[i], err)
+		}
+		parts = append(parts, unescaped)
+	}
+	return parts, nil
+}
+
+// Load loads the parts of the schema resource id onto the given variables. An empty id loads empty parts.
+func (c IDCodec) Load(d *schema.ResourceData, parts ...*string) diag.Diagnostics {
+	if len(parts) != len(c.names) {
+		return diag.Errorf("the number of parts to load (%d) did not match the number of parts of the id (%d)", len(parts), len(c.names))
+	}
 
 	// handle the empty id case
-	if idString == "" {
+	if d.Id() == "" {
 		for i := range parts {
 			*parts[i] = ""
 		}
 		return nil
 	}
 
-	idParts := strings.Split(idString, idPartsDelimeter)
-	if len(idParts) != len(parts) {
-		return diag.Errorf("the number of parts on the schema resource id (%d) did not match the number of parts expected (%d)", len(idParts), len(parts))
+	decoded, err := c.Decode(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	for i, idPart := range idParts {
-		*parts[i] = idPart
+	for i, part := range decoded {
+		*parts[i] = part
 	}
 	return nil
 }
 
-// SetMultipartID sets the id on the schema resource data concatenating multiple parts.
-func SetMultipartID(d *schema.ResourceData, parts ...string) {
-	d.SetId(strings.Join(parts, idPartsDelimeter))
+// Set sets the id on the schema resource data, encoding the given parts.
+func (c IDCodec) Set(d *schema.ResourceData, parts ...string) {
+	d.SetId(c.Encode(parts...))
+}
+
+// ImportStateContext validates the id of an import, so it fails with the expected format rather
+// than when the resource is read.
+func (c IDCodec) ImportStateContext(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	if _, err := c.Decode(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// LegacyIDStateUpgrader returns a state upgrader from the given schema version, for states whose id
+// joined the parts without escaping them. The legacy order lists, for each part of the codec, its
+// position in the legacy id, and is only needed when the parts were in a different order.
+func (c IDCodec) LegacyIDStateUpgrader(version int, r *schema.Resource, legacyOrder ...int) schema.StateUpgrader {
+	if len(legacyOrder) == 0 {
+		for i := range c.names {
+			legacyOrder = append(legacyOrder, i)
+		}
+	}
+
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    r.CoreConfigSchema().ImpliedType(),
+		Upgrade: func(ctx context.Context, rawState map[string]any, meta any) (map[string]any, error) {
+			id, _ := rawState["id"].(string)
+			if id == "" {
+				return rawState, nil
+			}
+
+			legacyParts := strings.Split(id, idPartsDelimiter)
+			if len(legacyParts) != len(c.names) {
+				return nil, fmt.Errorf("failed to migrate id %q to the format %s, expected %d parts separated by %q, got %d parts", id, c.Format(), len(c.names), idPartsDelimiter, len(legacyParts))
+			}
+			parts := make([]string, 0, len(legacyParts))
+			for _, i := range legacyOrder {
+				parts = append(parts, legacyParts[i])
+			}
+			rawState["id"] = c.Encode(parts...)
+			return rawState, nil
+		},
+	}
 }
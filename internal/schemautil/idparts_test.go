@@ -0,0 +1,98 @@
+package schemautil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDCodec(t *testing.T) {
+	codec := NewIDCodec("connector_id", "token_id")
+	assert.Equal(t, "connector_id:token_id", codec.Format())
+
+	tests := []struct {
+		name  string
+		parts []string
+		id    string
+	}{
+		{name: "plain parts", parts: []string{"connector", "token"}, id: "connector:token"},
+		{name: "delimiter in a part", parts: []string{"connector:eu", "token"}, id: "connector%3Aeu:token"},
+		{name: "escape character in a part", parts: []string{"100%", "token"}, id: "100%25:token"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := codec.Encode(test.parts...)
+			assert.Equal(t, test.id, id)
+
+			parts, err := codec.Decode(id)
+			require.NoError(t, err)
+			assert.Equal(t, test.parts, parts)
+		})
+	}
+}
+
+func TestIDCodecDecodeErrors(t *testing.T) {
+	codec := NewIDCodec("policy_id", "socket_id")
+
+	tests := []struct {
+		name string
+		id   string
+		err  string
+	}{
+		{
+			name: "too few parts",
+			id:   "policy",
+			err:  `invalid id "policy", expected format is policy_id:socket_id with 2 parts separated by ":", got 1 parts`,
+		},
+		{
+			name: "too many parts",
+			id:   "policy:socket:extra",
+			err:  `invalid id "policy:socket:extra", expected format is policy_id:socket_id with 2 parts separated by ":", got 3 parts`,
+		},
+		{
+			name: "empty part",
+			id:   "policy:",
+			err:  `invalid id "policy:", expected format is policy_id:socket_id, socket_id must not be empty`,
+		},
+		{
+			name: "bad escape",
+			id:   "policy%zz:socket",
+			err:  `invalid id "policy%zz:socket", expected format is policy_id:socket_id, policy_id is not escaped correctly`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := codec.Decode(test.id)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestIDCodecLegacyIDStateUpgrader(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+		},
+	}
+
+	upgrader := NewIDCodec("service_account_name", "token_id").LegacyIDStateUpgrader(0, r, 1, 0)
+	assert.Equal(t, 0, upgrader.Version)
+
+	upgraded, err := upgrader.Upgrade(context.Background(), map[string]any{"id": "token:100%-service"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "100%25-service:token", upgraded["id"])
+
+	// same order, parts without special characters are unchanged
+	upgrader = NewIDCodec("policy_id", "socket_id").LegacyIDStateUpgrader(0, r)
+	upgraded, err = upgrader.Upgrade(context.Background(), map[string]any{"id": "policy:socket"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "policy:socket", upgraded["id"])
+
+	_, err = upgrader.Upgrade(context.Background(), map[string]any{"id": "policy"}, nil)
+	assert.ErrorContains(t, err, `failed to migrate id "policy" to the format policy_id:socket_id`)
+}